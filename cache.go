@@ -0,0 +1,363 @@
+package probes
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// DefaultCacheInterval содержит интервал фоновых
+// проверок CachedProbe, который используется вместо
+// нулевого или отрицательного интервала. Совпадает с
+// periodSeconds пробы Kubernetes по умолчанию.
+const DefaultCacheInterval = 10 * time.Second
+
+var (
+	// ErrNotChecked указывает, что фоновая проверка
+	// CachedProbe ещё ни разу не была выполнена.
+	ErrNotChecked = errors.New("probes: probe has not been checked yet")
+
+	// ErrStaleResult указывает, что результат CachedProbe
+	// устарел и больше не может считаться достоверным.
+	//
+	//	Смотри WithMaxStaleness
+	ErrStaleResult = errors.New("probes: stale result")
+)
+
+// CacheOption настраивает CachedProbe.
+type CacheOption func(*CachedProbe)
+
+// WithMaxStaleness задаёт время, по истечении которого
+// результат последней проверки считается устаревшим и
+// заменяется на Failure с ошибкой ErrStaleResult.
+//
+// По умолчанию используется утроенный интервал проверки.
+func WithMaxStaleness(staleness time.Duration) CacheOption {
+	return func(probe *CachedProbe) {
+		probe.maxStaleness = staleness
+	}
+}
+
+// CachedProbe выполняет пробы в фоне с заданным
+// интервалом и мгновенно отдаёт последний полученный
+// результат вместо выполнения проверки на каждый
+// HTTP-запрос.
+//
+// Liveness-, Readiness- и Startup-пробы выполняются и
+// кэшируются раздельно. Если пробы не различают виды,
+// как ProbeFunc, ReportFunc и проба одного вида в
+// NewCachedReadiness, она выполняется один раз за
+// интервал.
+//
+// Кэшируется расширенный отчёт целиком, поэтому
+// HTTP-обработчики получают подробности и результаты
+// проверок исходных проб, например CompositeProbes.
+// Именованные проверки и параметр ?exclude обслуживаются
+// из последнего отчёта без повторного выполнения.
+//
+// Для инициализации необходимо использовать метод
+// NewCachedProbe, а для запуска фоновых проверок –
+// метод CachedProbe.Start.
+type CachedProbe struct {
+	interval     time.Duration
+	maxStaleness time.Duration
+
+	liveness  *cachedReport
+	readiness *cachedReport
+	startup   *cachedReport
+
+	mu   sync.Mutex
+	stop chan struct{}
+	done chan struct{}
+}
+
+// NewCachedProbe инициализирует кэширующую обёртку над
+// пробами, которые выполняются каждые interval.
+//
+// Время выполнения каждой проверки ограничено интервалом.
+// Нулевой или отрицательный интервал заменяется
+// DefaultCacheInterval. Функцию можно обернуть,
+// преобразовав её к ProbeFunc, а пробу одного вида –
+// методами NewCachedLiveness, NewCachedReadiness и
+// NewCachedStartup.
+func NewCachedProbe(probes Probes, interval time.Duration, options ...CacheOption) *CachedProbe {
+	if interval <= 0 {
+		interval = DefaultCacheInterval
+	}
+
+	cached := &CachedProbe{
+		interval:     interval,
+		maxStaleness: 3 * interval,
+		liveness:     newCachedReport(livenessReporter(probes)),
+	}
+
+	switch probes.(type) {
+	case ProbeFunc, ReportFunc, singleProbe:
+		cached.readiness, cached.startup = cached.liveness, cached.liveness
+	default:
+		cached.readiness = newCachedReport(readinessReporter(probes))
+		cached.startup = newCachedReport(startupReporter(probes))
+	}
+
+	for _, option := range options {
+		option(cached)
+	}
+
+	return cached
+}
+
+// NewCachedLiveness инициализирует кэширующую обёртку
+// над Liveness-пробой, например RuntimeChecker, которая
+// выполняется каждые interval.
+//
+// В отличие от преобразования к ProbeFunc, сохраняются
+// расширенный отчёт и именованные проверки пробы. Все
+// виды проб обёртки возвращают её последний отчёт.
+//
+//	Смотри NewCachedProbe
+func NewCachedLiveness(probe Liveness, interval time.Duration, options ...CacheOption) *CachedProbe {
+	return NewCachedProbe(livenessOnly(probe), interval, options...)
+}
+
+// NewCachedReadiness инициализирует кэширующую обёртку
+// над Readiness-пробой, например SQLChecker или
+// UpstreamChecker.
+//
+//	Смотри NewCachedLiveness
+func NewCachedReadiness(probe Readiness, interval time.Duration, options ...CacheOption) *CachedProbe {
+	return NewCachedProbe(readinessOnly(probe), interval, options...)
+}
+
+// NewCachedStartup инициализирует кэширующую обёртку
+// над Startup-пробой.
+//
+//	Смотри NewCachedLiveness
+func NewCachedStartup(probe Startup, interval time.Duration, options ...CacheOption) *CachedProbe {
+	return NewCachedProbe(startupOnly(probe), interval, options...)
+}
+
+// Start выполняет первую проверку и запускает фоновые
+// проверки, которые выполняются до вызова
+// CachedProbe.Stop или отмены ctx.
+//
+// Повторный вызов без остановки ничего не делает. После
+// отмены ctx фоновые проверки можно запустить снова.
+func (probe *CachedProbe) Start(ctx context.Context) {
+	probe.mu.Lock()
+	defer probe.mu.Unlock()
+
+	if probe.stop != nil {
+		return
+	}
+
+	probe.stop = make(chan struct{})
+	probe.done = make(chan struct{})
+
+	go probe.poll(ctx, probe.stop, probe.done)
+}
+
+// Stop останавливает фоновые проверки и дожидается
+// завершения текущей проверки.
+func (probe *CachedProbe) Stop() {
+	probe.mu.Lock()
+	stop, done := probe.stop, probe.done
+	probe.stop, probe.done = nil, nil
+	probe.mu.Unlock()
+
+	if stop == nil {
+		return
+	}
+
+	close(stop)
+	<-done
+}
+
+func (probe *CachedProbe) Liveness(ctx context.Context) (Result, error) {
+	return probe.LivenessReport(ctx).Unwrap()
+}
+
+func (probe *CachedProbe) Readiness(ctx context.Context) (Result, error) {
+	return probe.ReadinessReport(ctx).Unwrap()
+}
+
+func (probe *CachedProbe) Startup(ctx context.Context) (Result, error) {
+	return probe.StartupReport(ctx).Unwrap()
+}
+
+// LivenessReport возвращает последний отчёт
+// Liveness-пробы.
+//
+// До первой проверки возвращается Unknown с ошибкой
+// ErrNotChecked, а после превышения WithMaxStaleness –
+// Failure с ошибкой ErrStaleResult. Проверки, указанные
+// в WithExcludedChecks, исключаются из отчёта.
+func (probe *CachedProbe) LivenessReport(ctx context.Context) Report {
+	return probe.liveness.load(ctx, probe.maxStaleness)
+}
+
+// ReadinessReport возвращает последний отчёт
+// Readiness-пробы.
+//
+//	Смотри CachedProbe.LivenessReport
+func (probe *CachedProbe) ReadinessReport(ctx context.Context) Report {
+	return probe.readiness.load(ctx, probe.maxStaleness)
+}
+
+// StartupReport возвращает последний отчёт
+// Startup-пробы.
+//
+//	Смотри CachedProbe.LivenessReport
+func (probe *CachedProbe) StartupReport(ctx context.Context) Report {
+	return probe.startup.load(ctx, probe.maxStaleness)
+}
+
+// LivenessCheck возвращает результат именованной
+// проверки из последнего отчёта Liveness-пробы. До
+// первой проверки возвращается false.
+func (probe *CachedProbe) LivenessCheck(_ context.Context, name string) (CheckResult, bool) {
+	return probe.liveness.find(name, probe.maxStaleness)
+}
+
+// ReadinessCheck возвращает результат именованной
+// проверки из последнего отчёта Readiness-пробы.
+//
+//	Смотри CachedProbe.LivenessCheck
+func (probe *CachedProbe) ReadinessCheck(_ context.Context, name string) (CheckResult, bool) {
+	return probe.readiness.find(name, probe.maxStaleness)
+}
+
+// StartupCheck возвращает результат именованной
+// проверки из последнего отчёта Startup-пробы.
+//
+//	Смотри CachedProbe.LivenessCheck
+func (probe *CachedProbe) StartupCheck(_ context.Context, name string) (CheckResult, bool) {
+	return probe.startup.find(name, probe.maxStaleness)
+}
+
+func (probe *CachedProbe) poll(ctx context.Context, stop <-chan struct{}, done chan<- struct{}) {
+	defer close(done)
+	defer probe.release(stop)
+
+	ticker := time.NewTicker(probe.interval)
+	defer ticker.Stop()
+
+	reports := []*cachedReport{probe.liveness}
+	if probe.readiness != probe.liveness {
+		reports = append(reports, probe.readiness, probe.startup)
+	}
+
+	for {
+		for _, report := range reports {
+			report.check(ctx, probe.interval)
+		}
+
+		select {
+		case <-ticker.C:
+		case <-stop:
+			return
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// release сбрасывает завершившиеся фоновые проверки,
+// чтобы их можно было запустить снова после отмены ctx.
+func (probe *CachedProbe) release(stop <-chan struct{}) {
+	probe.mu.Lock()
+	defer probe.mu.Unlock()
+
+	if probe.stop == stop {
+		probe.stop, probe.done = nil, nil
+	}
+}
+
+type cachedReport struct {
+	report reporter
+
+	mu        sync.RWMutex
+	last      Report
+	checkedAt time.Time
+}
+
+func newCachedReport(report reporter) *cachedReport {
+	return &cachedReport{report: report, last: Report{Result: Unknown, Err: ErrNotChecked}}
+}
+
+func (cached *cachedReport) check(ctx context.Context, timeout time.Duration) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	report := cached.report(ctx)
+	if report.Result.Validate() != nil {
+		report.Result, report.Err = Failure, ErrUnsupportedResult
+	}
+
+	// Отчёт может отдаваться пробам разных видов, поэтому
+	// вид пробы задаёт HTTP-обработчик.
+	report.Probe = ""
+
+	cached.mu.Lock()
+	defer cached.mu.Unlock()
+
+	cached.last, cached.checkedAt = report, time.Now()
+}
+
+func (cached *cachedReport) load(ctx context.Context, maxStaleness time.Duration) Report {
+	cached.mu.RLock()
+	defer cached.mu.RUnlock()
+
+	if err := cached.stale(maxStaleness); err != nil {
+		return Report{Result: Failure, Err: err}
+	}
+
+	report := cached.last
+
+	if excluded := excludedChecks(ctx); len(excluded) > 0 && len(report.Checks) > 0 {
+		var included CheckResults
+
+		for _, check := range report.Checks {
+			if !excluded[check.Name] {
+				included = append(included, check)
+			}
+		}
+
+		report.Checks = included
+		report.Result, report.Err = included.Unwrap()
+	}
+
+	return report
+}
+
+func (cached *cachedReport) find(name string, maxStaleness time.Duration) (CheckResult, bool) {
+	cached.mu.RLock()
+	defer cached.mu.RUnlock()
+
+	for _, check := range cached.last.Checks {
+		if check.Name != name {
+			continue
+		}
+
+		if err := cached.stale(maxStaleness); err != nil {
+			check.Result, check.Err = Failure, err
+		}
+
+		return check, true
+	}
+
+	return CheckResult{}, false
+}
+
+func (cached *cachedReport) stale(maxStaleness time.Duration) error {
+	if cached.checkedAt.IsZero() || maxStaleness <= 0 {
+		return nil
+	}
+
+	if staleness := time.Since(cached.checkedAt); staleness > maxStaleness {
+		return fmt.Errorf("%w: last checked %s ago", ErrStaleResult, staleness.Truncate(time.Millisecond))
+	}
+
+	return nil
+}
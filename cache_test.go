@@ -0,0 +1,282 @@
+package probes
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewCachedProbe(t *testing.T) {
+	t.Parallel()
+
+	// Act.
+	probe := NewCachedProbe(testProbe(Success, nil), time.Second)
+
+	// Assert.
+	assert.Equal(t, time.Second, probe.interval)
+	assert.Equal(t, 3*time.Second, probe.maxStaleness)
+
+	result, err := probe.Readiness(context.Background())
+
+	assert.Equal(t, Unknown, result)
+	assert.Equal(t, ErrNotChecked, err)
+}
+
+func TestNewCachedProbe_Interval(t *testing.T) {
+	t.Parallel()
+
+	// Act.
+	probe := NewCachedProbe(testProbe(Success, nil), 0)
+
+	// Assert.
+	assert.Equal(t, DefaultCacheInterval, probe.interval)
+	assert.Equal(t, 3*DefaultCacheInterval, probe.maxStaleness)
+
+	probe.Start(context.Background())
+	probe.Stop()
+}
+
+func TestCachedProbe_Start_Restart(t *testing.T) {
+	t.Parallel()
+
+	// Arrange.
+	var calls int32
+
+	probe := NewCachedProbe(ProbeFunc(func(context.Context) (Result, error) {
+		atomic.AddInt32(&calls, 1)
+
+		return Success, nil
+	}), time.Hour)
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	probe.Start(ctx)
+
+	require.Eventually(t, func() bool {
+		return atomic.LoadInt32(&calls) == 1
+	}, time.Second, time.Millisecond)
+
+	cancel()
+
+	require.Eventually(t, func() bool {
+		probe.mu.Lock()
+		defer probe.mu.Unlock()
+
+		return probe.stop == nil
+	}, time.Second, time.Millisecond)
+
+	// Act.
+	probe.Start(context.Background())
+	defer probe.Stop()
+
+	// Assert.
+	assert.Eventually(t, func() bool {
+		return atomic.LoadInt32(&calls) == 2
+	}, time.Second, time.Millisecond)
+}
+
+func TestCachedProbe_Start(t *testing.T) {
+	t.Parallel()
+
+	// Arrange.
+	var calls int32
+
+	probe := NewCachedProbe(ProbeFunc(func(context.Context) (Result, error) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			return Warning, errDummyProbe
+		}
+
+		return Success, nil
+	}), 20*time.Millisecond)
+
+	// Act.
+	probe.Start(context.Background())
+	defer probe.Stop()
+
+	// Assert.
+	assert.Eventually(t, func() bool {
+		result, err := probe.Liveness(context.Background())
+
+		return result == Warning && err == errDummyProbe
+	}, time.Second, time.Millisecond)
+
+	assert.Eventually(t, func() bool {
+		result, err := probe.Startup(context.Background())
+
+		return result == Success && err == nil
+	}, time.Second, time.Millisecond)
+}
+
+func TestCachedProbe_Stop(t *testing.T) {
+	t.Parallel()
+
+	// Arrange.
+	var calls int32
+
+	probe := NewCachedProbe(ProbeFunc(func(context.Context) (Result, error) {
+		atomic.AddInt32(&calls, 1)
+
+		return Success, nil
+	}), 10*time.Millisecond)
+
+	probe.Start(context.Background())
+
+	// Act.
+	probe.Stop()
+
+	stopped := atomic.LoadInt32(&calls)
+
+	time.Sleep(50 * time.Millisecond)
+
+	// Assert.
+	assert.Equal(t, stopped, atomic.LoadInt32(&calls))
+
+	probe.Stop()
+}
+
+func TestCachedProbe_Readiness_Stale(t *testing.T) {
+	t.Parallel()
+
+	// Arrange.
+	probe := NewCachedProbe(testProbe(Success, nil), time.Hour, WithMaxStaleness(30*time.Millisecond))
+
+	probe.Start(context.Background())
+	defer probe.Stop()
+
+	assert.Eventually(t, func() bool {
+		result, err := probe.Readiness(context.Background())
+
+		return result == Success && err == nil
+	}, time.Second, time.Millisecond)
+
+	// Act.
+	time.Sleep(50 * time.Millisecond)
+
+	result, err := probe.Readiness(context.Background())
+
+	// Assert.
+	assert.Equal(t, Failure, result)
+	assert.True(t, errors.Is(err, ErrStaleResult))
+}
+
+func TestCachedProbe_Handler(t *testing.T) {
+	t.Parallel()
+
+	// Arrange.
+	var calls int32
+
+	composite := NewCompositeProbes().
+		RegisterReadiness("postgres", ProbeFunc(func(context.Context) (Result, error) {
+			atomic.AddInt32(&calls, 1)
+
+			return Success, nil
+		})).
+		RegisterReadiness("redis", testProbe(Failure, errDummyProbe))
+
+	probe := NewCachedProbe(composite, time.Hour)
+
+	server := NewHTTPServer().Probes(probe)
+
+	serve := func(target string) (int, string) {
+		recorder := httptest.NewRecorder()
+
+		server.Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, target, nil))
+
+		return recorder.Code, recorder.Body.String()
+	}
+
+	probe.Start(context.Background())
+	defer probe.Stop()
+
+	require.Eventually(t, func() bool {
+		return atomic.LoadInt32(&calls) == 1
+	}, time.Second, time.Millisecond)
+
+	// Act.
+	status, body := serve("/readiness?verbose&exclude=redis")
+	checkStatus, checkBody := serve("/readiness/redis")
+	unknownStatus, _ := serve("/readiness/kafka")
+
+	// Assert.
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "[+]postgres ok\nreadiness check passed\n", body)
+
+	assert.Equal(t, http.StatusInternalServerError, checkStatus)
+	assert.Equal(t, "redis: probes: dummy error", checkBody)
+	assert.Equal(t, http.StatusNotFound, unknownStatus)
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
+
+func TestCachedProbe_Handler_Probe(t *testing.T) {
+	t.Parallel()
+
+	// Arrange.
+	metrics := NewMetrics()
+
+	probe := NewCachedProbe(testProbe(Success, nil), time.Hour)
+
+	server := NewHTTPServer().Probes(probe, WithMetrics(metrics)).Metrics(metrics)
+
+	probe.Start(context.Background())
+	defer probe.Stop()
+
+	require.Eventually(t, func() bool {
+		return probe.ReadinessReport(context.Background()).Err == nil
+	}, time.Second, time.Millisecond)
+
+	recorder := httptest.NewRecorder()
+
+	// Act.
+	server.Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/readiness?verbose", nil))
+
+	// Assert.
+	assert.Equal(t, "[+]readiness ok\nreadiness check passed\n", recorder.Body.String())
+
+	recorder = httptest.NewRecorder()
+	server.Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, DefaultMetricsPath, nil))
+
+	assert.Contains(t, recorder.Body.String(), `probes_probe_duration_seconds_count{probe="readiness"} 1`)
+	assert.NotContains(t, recorder.Body.String(), `probe="liveness"`)
+}
+
+func TestNewCachedReadiness(t *testing.T) {
+	t.Parallel()
+
+	// Arrange.
+	var calls int32
+
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		atomic.AddInt32(&calls, 1)
+	}))
+	defer upstream.Close()
+
+	probe := NewCachedReadiness(NewUpstreamChecker(upstream.URL), time.Hour)
+
+	probe.Start(context.Background())
+	defer probe.Stop()
+
+	require.Eventually(t, func() bool {
+		return probe.ReadinessReport(context.Background()).Err == nil
+	}, time.Second, time.Millisecond)
+
+	// Act.
+	readiness := probe.ReadinessReport(context.Background())
+	liveness := probe.LivenessReport(context.Background())
+
+	// Assert.
+	assert.Equal(t, Success, readiness.Result)
+	assert.Equal(t, http.StatusOK, readiness.Details["status_code"])
+	assert.Equal(t, "component", readiness.Component.Type)
+	assert.Empty(t, readiness.Probe)
+	assert.Equal(t, readiness, liveness)
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
@@ -0,0 +1,215 @@
+// Команда probes запрашивает у запущенного процесса
+// результат Liveness-, Readiness- или Startup-пробы и
+// используется в exec-пробах Kubernetes вместо curl.
+//
+// Эндпоинт пробы опрашивается по HTTP, через unix-сокет
+// или по протоколу gRPC Health Checking:
+//
+//	probes readiness
+//	probes -http http://localhost:8080 -path /readyz readiness
+//	probes -unix /var/run/app/probes.sock liveness
+//	probes -grpc localhost:9090 startup
+//	probes -check postgres readiness
+//
+// Команда завершается с кодом 0, если проба вернула
+// Success или Warning, с кодом 1, если Failure, и с
+// кодом 2, если пробу не удалось выполнить. Тело ответа
+// выводится в стандартный вывод.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health/grpc_health_v1"
+
+	"github.com/mlaymer/probes"
+)
+
+const (
+	exitSuccess = 0
+	exitFailure = 1
+	exitError   = 2
+)
+
+var paths = map[string]string{
+	"liveness":  probes.DefaultLivenessPath,
+	"readiness": probes.DefaultReadinessPath,
+	"startup":   probes.DefaultStartupPath,
+}
+
+type options struct {
+	probe   string
+	http    string
+	unix    string
+	grpc    string
+	path    string
+	check   string
+	timeout time.Duration
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+func run(args []string, stdout, stderr io.Writer) int {
+	options, err := parse(args, stderr)
+	if err != nil {
+		return exitError
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), options.timeout)
+	defer cancel()
+
+	var (
+		result probes.Result
+		body   string
+	)
+
+	if options.grpc != "" {
+		result, body, err = checkGRPC(ctx, options)
+	} else {
+		result, body, err = checkHTTP(ctx, options)
+	}
+
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+
+		return exitError
+	}
+
+	if body != "" {
+		fmt.Fprintln(stdout, strings.TrimRight(body, "\n"))
+	}
+
+	if result.IsFailure() {
+		return exitFailure
+	}
+
+	return exitSuccess
+}
+
+func parse(args []string, stderr io.Writer) (options, error) {
+	var options options
+
+	flags := flag.NewFlagSet("probes", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: probes [flags] liveness|readiness|startup")
+		flags.PrintDefaults()
+	}
+
+	flags.StringVar(&options.http, "http", "http://"+probes.DefaultServerAddress, "base URL of the HTTP probe server")
+	flags.StringVar(&options.unix, "unix", "", "path to the unix socket of the HTTP probe server")
+	flags.StringVar(&options.grpc, "grpc", "", "address of the gRPC Health Checking server")
+	flags.StringVar(&options.path, "path", "", "HTTP path of the probe endpoint (default /liveness, /readiness or /startup)")
+	flags.StringVar(&options.check, "check", "", "name of a single check to query")
+	flags.DurationVar(&options.timeout, "timeout", time.Second, "probe timeout")
+
+	if err := flags.Parse(args); err != nil {
+		return options, err
+	}
+
+	if flags.NArg() != 1 {
+		flags.Usage()
+
+		return options, errors.New("probe kind is required")
+	}
+
+	options.probe = flags.Arg(0)
+
+	if _, found := paths[options.probe]; !found {
+		err := fmt.Errorf("unsupported probe %q", options.probe)
+
+		fmt.Fprintln(stderr, err)
+		flags.Usage()
+
+		return options, err
+	}
+
+	if options.path == "" {
+		options.path = paths[options.probe]
+	}
+
+	return options, nil
+}
+
+func checkHTTP(ctx context.Context, options options) (probes.Result, string, error) {
+	client := http.Client{}
+	base := options.http
+
+	if options.unix != "" {
+		client.Transport = &http.Transport{
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				var dialer net.Dialer
+
+				return dialer.DialContext(ctx, "unix", options.unix)
+			},
+		}
+
+		base = "http://unix"
+	}
+
+	target := strings.TrimSuffix(base, "/") + options.path
+	if options.check != "" {
+		target += "/" + url.PathEscape(options.check)
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return probes.Failure, "", err
+	}
+
+	response, err := client.Do(request)
+	if err != nil {
+		return probes.Failure, "", err
+	}
+	defer response.Body.Close()
+
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return probes.Failure, "", err
+	}
+
+	if response.StatusCode >= http.StatusOK && response.StatusCode < http.StatusMultipleChoices {
+		return probes.Success, string(body), nil
+	}
+
+	return probes.Failure, string(body), nil
+}
+
+func checkGRPC(ctx context.Context, options options) (probes.Result, string, error) {
+	conn, err := grpc.DialContext(ctx, options.grpc, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return probes.Failure, "", err
+	}
+	defer conn.Close()
+
+	service := options.probe
+	if options.check != "" {
+		service += "/" + options.check
+	}
+
+	response, err := grpc_health_v1.NewHealthClient(conn).Check(ctx, &grpc_health_v1.HealthCheckRequest{Service: service})
+	if err != nil {
+		return probes.Failure, "", err
+	}
+
+	status := response.GetStatus()
+	if status == grpc_health_v1.HealthCheckResponse_SERVING {
+		return probes.Success, status.String(), nil
+	}
+
+	return probes.Failure, status.String(), nil
+}
@@ -0,0 +1,184 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	"github.com/mlaymer/probes"
+)
+
+var errDummy = errors.New("probes: dummy error")
+
+func testProbes() probes.Probes {
+	return probes.NewCompositeProbes().
+		RegisterLiveness("deadlock-watchdog", probes.ProbeFunc(func(context.Context) (probes.Result, error) {
+			return probes.Success, nil
+		})).
+		RegisterReadiness("postgres", probes.ProbeFunc(func(context.Context) (probes.Result, error) {
+			return probes.Warning, errDummy
+		})).
+		RegisterReadiness("redis", probes.ProbeFunc(func(context.Context) (probes.Result, error) {
+			return probes.Failure, errDummy
+		}))
+}
+
+func TestRun_HTTP(t *testing.T) {
+	t.Parallel()
+
+	// Arrange.
+	server := httptest.NewServer(probes.NewHTTPServer().Probes(testProbes()).Handler())
+	defer server.Close()
+
+	tests := []struct {
+		name           string
+		args           []string
+		expectedCode   int
+		expectedStdout string
+	}{
+		{
+			name:           `Результат "Success"`,
+			args:           []string{"-http", server.URL, "liveness"},
+			expectedCode:   exitSuccess,
+			expectedStdout: "",
+		},
+		{
+			name:           `Результат "Warning"`,
+			args:           []string{"-http", server.URL, "-check", "postgres", "readiness"},
+			expectedCode:   exitSuccess,
+			expectedStdout: "postgres: probes: dummy error\n",
+		},
+		{
+			name:           `Результат "Failure"`,
+			args:           []string{"-http", server.URL, "readiness"},
+			expectedCode:   exitFailure,
+			expectedStdout: "postgres: probes: dummy error; redis: probes: dummy error\n",
+		},
+		{
+			name:           "Неизвестный путь",
+			args:           []string{"-http", server.URL, "-path", "/readyz", "readiness"},
+			expectedCode:   exitFailure,
+			expectedStdout: "404 page not found\n",
+		},
+		{
+			name:           "Неподдерживаемая проба",
+			args:           []string{"-http", server.URL, "healthz"},
+			expectedCode:   exitError,
+			expectedStdout: "",
+		},
+		{
+			name:           "Без пробы",
+			args:           []string{"-http", server.URL},
+			expectedCode:   exitError,
+			expectedStdout: "",
+		},
+		{
+			name:           "Сервер недоступен",
+			args:           []string{"-http", "http://127.0.0.1:1", "liveness"},
+			expectedCode:   exitError,
+			expectedStdout: "",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+
+			// Act.
+			code := run(test.args, &stdout, &stderr)
+
+			// Assert.
+			assert.Equal(t, test.expectedCode, code)
+			assert.Equal(t, test.expectedStdout, stdout.String())
+		})
+	}
+}
+
+func TestRun_Unix(t *testing.T) {
+	t.Parallel()
+
+	// Arrange.
+	socket := filepath.Join(t.TempDir(), "probes.sock")
+
+	listener, err := net.Listen("unix", socket)
+	require.NoError(t, err)
+
+	server := &http.Server{Handler: probes.NewHTTPServer().Probes(testProbes()).Handler()}
+	defer server.Close()
+
+	go func() {
+		_ = server.Serve(listener)
+	}()
+
+	var stdout, stderr bytes.Buffer
+
+	// Act.
+	code := run([]string{"-unix", socket, "-check", "redis", "readiness"}, &stdout, &stderr)
+
+	// Assert.
+	assert.Equal(t, exitFailure, code)
+	assert.Equal(t, "redis: probes: dummy error\n", stdout.String())
+}
+
+func TestRun_GRPC(t *testing.T) {
+	t.Parallel()
+
+	// Arrange.
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	server := grpc.NewServer()
+	probes.NewGRPCHealthServer(testProbes()).Register(server)
+
+	defer server.Stop()
+
+	go func() {
+		_ = server.Serve(listener)
+	}()
+
+	tests := []struct {
+		name           string
+		args           []string
+		expectedCode   int
+		expectedStdout string
+	}{
+		{
+			name:           "Сервис доступен",
+			args:           []string{"-grpc", listener.Addr().String(), "liveness"},
+			expectedCode:   exitSuccess,
+			expectedStdout: "SERVING\n",
+		},
+		{
+			name:           "Сервис недоступен",
+			args:           []string{"-grpc", listener.Addr().String(), "readiness"},
+			expectedCode:   exitFailure,
+			expectedStdout: "NOT_SERVING\n",
+		},
+		{
+			name:           "Неизвестная проверка",
+			args:           []string{"-grpc", listener.Addr().String(), "-check", "kafka", "readiness"},
+			expectedCode:   exitError,
+			expectedStdout: "",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+
+			// Act.
+			code := run(test.args, &stdout, &stderr)
+
+			// Assert.
+			assert.Equal(t, test.expectedCode, code)
+			assert.Equal(t, test.expectedStdout, stdout.String())
+		})
+	}
+}
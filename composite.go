@@ -0,0 +1,448 @@
+package probes
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+)
+
+// CompositeProbes объединяет именованные проверки
+// в Liveness-, Readiness- и Startup-пробы Kubernetes.
+//
+// Для каждого вида пробы регистрируется свой набор
+// проверок. При обработке пробы все проверки выполняются
+// параллельно, а их результаты объединяются с помощью
+// Aggregate: любой Failure приводит к Failure, любой
+// Warning – к Warning.
+//
+// Для инициализации необходимо использовать метод
+// NewCompositeProbes.
+type CompositeProbes struct {
+	liveness  checks
+	readiness checks
+	startup   checks
+}
+
+// NewCompositeProbes инициализирует пробы Kubernetes
+// без зарегистрированных проверок.
+//
+// Пробы без проверок всегда возвращают Success.
+func NewCompositeProbes() *CompositeProbes {
+	return &CompositeProbes{}
+}
+
+// RegisterLiveness регистрирует именованную проверку
+// для Liveness-пробы.
+//
+// Повторная регистрация проверки с тем же именем
+// заменяет ранее зарегистрированную проверку.
+//
+//	Смотри CheckOption
+func (probes *CompositeProbes) RegisterLiveness(name string, probe Liveness, options ...CheckOption) *CompositeProbes {
+	probes.liveness.register(newCheck(name, livenessReporter(probe), options))
+
+	return probes
+}
+
+// RegisterReadiness регистрирует именованную проверку
+// для Readiness-пробы.
+//
+// Повторная регистрация проверки с тем же именем
+// заменяет ранее зарегистрированную проверку.
+//
+//	Смотри CheckOption
+func (probes *CompositeProbes) RegisterReadiness(name string, probe Readiness, options ...CheckOption) *CompositeProbes {
+	probes.readiness.register(newCheck(name, readinessReporter(probe), options))
+
+	return probes
+}
+
+// RegisterStartup регистрирует именованную проверку
+// для Startup-пробы.
+//
+// Повторная регистрация проверки с тем же именем
+// заменяет ранее зарегистрированную проверку.
+//
+//	Смотри CheckOption
+func (probes *CompositeProbes) RegisterStartup(name string, probe Startup, options ...CheckOption) *CompositeProbes {
+	probes.startup.register(newCheck(name, startupReporter(probe), options))
+
+	return probes
+}
+
+func (probes *CompositeProbes) Liveness(ctx context.Context) (Result, error) {
+	return probes.LivenessChecks(ctx).Unwrap()
+}
+
+func (probes *CompositeProbes) Readiness(ctx context.Context) (Result, error) {
+	return probes.ReadinessChecks(ctx).Unwrap()
+}
+
+func (probes *CompositeProbes) Startup(ctx context.Context) (Result, error) {
+	return probes.StartupChecks(ctx).Unwrap()
+}
+
+// LivenessChecks параллельно выполняет все проверки
+// Liveness-пробы и возвращает результат каждой из них.
+func (probes *CompositeProbes) LivenessChecks(ctx context.Context) CheckResults {
+	return probes.liveness.run(ctx)
+}
+
+// ReadinessChecks параллельно выполняет все проверки
+// Readiness-пробы и возвращает результат каждой из них.
+func (probes *CompositeProbes) ReadinessChecks(ctx context.Context) CheckResults {
+	return probes.readiness.run(ctx)
+}
+
+// StartupChecks параллельно выполняет все проверки
+// Startup-пробы и возвращает результат каждой из них.
+func (probes *CompositeProbes) StartupChecks(ctx context.Context) CheckResults {
+	return probes.startup.run(ctx)
+}
+
+// LivenessCheck выполняет проверку Liveness-пробы с
+// указанным именем. Если проверка не зарегистрирована,
+// возвращается false.
+func (probes *CompositeProbes) LivenessCheck(ctx context.Context, name string) (CheckResult, bool) {
+	return probes.liveness.runOne(ctx, name)
+}
+
+// ReadinessCheck выполняет проверку Readiness-пробы с
+// указанным именем. Если проверка не зарегистрирована,
+// возвращается false.
+func (probes *CompositeProbes) ReadinessCheck(ctx context.Context, name string) (CheckResult, bool) {
+	return probes.readiness.runOne(ctx, name)
+}
+
+// StartupCheck выполняет проверку Startup-пробы с
+// указанным именем. Если проверка не зарегистрирована,
+// возвращается false.
+func (probes *CompositeProbes) StartupCheck(ctx context.Context, name string) (CheckResult, bool) {
+	return probes.startup.runOne(ctx, name)
+}
+
+// CheckResult содержит результат выполнения одной
+// именованной проверки.
+type CheckResult struct {
+	// Name содержит имя проверки.
+	Name string
+
+	// Result содержит результат проверки.
+	Result Result
+
+	// Err содержит ошибку с отладочной информацией,
+	// которую вернула проверка.
+	//
+	//	Смотри Report.Unwrap
+	Err error
+
+	// Message содержит поясняющее сообщение проверки.
+	//
+	//	Смотри Report.Message
+	Message string
+
+	// Details содержит подробности проверки.
+	//
+	//	Смотри Report.Details
+	Details map[string]interface{}
+
+	// Component содержит описание проверяемого
+	// компонента.
+	//
+	//	Смотри Report.Component
+	Component Component
+
+	// Duration содержит время выполнения проверки.
+	Duration time.Duration
+}
+
+// IsHealthy возвращает true, если проверка вернула
+// Success без ошибки.
+func (result CheckResult) IsHealthy() bool {
+	return result.Result.IsSuccess() && result.Err == nil
+}
+
+// CheckResults содержит результаты выполнения
+// именованных проверок в порядке их регистрации.
+type CheckResults []CheckResult
+
+// Result возвращает объединённый результат всех проверок.
+//
+//	Смотри Aggregate
+func (results CheckResults) Result() Result {
+	aggregated := make([]Result, 0, len(results))
+
+	for _, result := range results {
+		aggregated = append(aggregated, result.Result)
+	}
+
+	return Aggregate(aggregated...)
+}
+
+// Err возвращает ChecksError, если хотя бы одна проверка
+// вернула ошибку или результат, отличный от Success,
+// иначе – nil.
+func (results CheckResults) Err() error {
+	var unhealthy CheckResults
+
+	for _, result := range results {
+		if !result.IsHealthy() {
+			unhealthy = append(unhealthy, result)
+		}
+	}
+
+	if len(unhealthy) == 0 {
+		return nil
+	}
+
+	return &ChecksError{Checks: unhealthy}
+}
+
+// Unwrap возвращает объединённый результат и ошибку
+// проверок в виде, который ожидают контракты
+// Liveness, Readiness и Startup.
+func (results CheckResults) Unwrap() (Result, error) {
+	return results.Result(), results.Err()
+}
+
+// ChecksError содержит результаты проверок, которые
+// вернули ошибку или результат, отличный от Success.
+type ChecksError struct {
+	Checks CheckResults
+}
+
+// Error возвращает описание каждой проверки в формате
+// "имя: ошибка", разделённые точкой с запятой.
+//
+// Если проверка не вернула ошибку, вместо неё
+// используется строковое значение результата.
+func (err *ChecksError) Error() string {
+	messages := make([]string, 0, len(err.Checks))
+
+	for _, check := range err.Checks {
+		message := check.Result.String()
+		if check.Err != nil {
+			message = check.Err.Error()
+		}
+
+		messages = append(messages, check.Name+": "+message)
+	}
+
+	return strings.Join(messages, "; ")
+}
+
+// DefaultCheckTimeout содержит время по умолчанию,
+// отведённое именованной проверке CompositeProbes,
+// совпадающее с timeoutSeconds пробы Kubernetes по
+// умолчанию.
+//
+//	Смотри WithTimeout
+const DefaultCheckTimeout = time.Second
+
+// ErrCheckTimeout указывает, что проверка не успела
+// завершиться за отведённое ей время.
+//
+//	Смотри WithTimeout
+var ErrCheckTimeout = errors.New("probes: check timed out")
+
+// CheckOption настраивает выполнение именованной
+// проверки в CompositeProbes.
+type CheckOption func(*check)
+
+// WithTimeout ограничивает время выполнения проверки.
+//
+// Контекст проверки наследуется от контекста входящей
+// пробы, поэтому проверка также завершается, когда
+// истекает время самой пробы. Если проверка не успела
+// завершиться, её результатом становится Failure
+// (смотри WithTimeoutResult) с ошибкой ErrCheckTimeout.
+//
+// По умолчанию используется DefaultCheckTimeout. Нулевое
+// или отрицательное значение отключает ограничение, и
+// проверка ограничена только временем самой пробы.
+func WithTimeout(timeout time.Duration) CheckOption {
+	return func(check *check) {
+		check.timeout = timeout
+	}
+}
+
+// WithTimeoutResult задаёт результат проверки, который
+// используется, если проверка не успела завершиться.
+//
+// По умолчанию используется Failure.
+func WithTimeoutResult(result Result) CheckOption {
+	return func(check *check) {
+		check.timeoutResult = result
+	}
+}
+
+type excludedChecksKey struct{}
+
+// WithExcludedChecks возвращает контекст, при обработке
+// пробы с которым CompositeProbes пропускает проверки
+// с указанными именами.
+//
+// HTTP-обработчики проб используют его для поддержки
+// параметра запроса ?exclude=имя.
+func WithExcludedChecks(ctx context.Context, names ...string) context.Context {
+	excluded := make(map[string]bool, len(names))
+
+	for name := range excludedChecks(ctx) {
+		excluded[name] = true
+	}
+
+	for _, name := range names {
+		excluded[name] = true
+	}
+
+	return context.WithValue(ctx, excludedChecksKey{}, excluded)
+}
+
+func excludedChecks(ctx context.Context) map[string]bool {
+	excluded, _ := ctx.Value(excludedChecksKey{}).(map[string]bool)
+
+	return excluded
+}
+
+type check struct {
+	name   string
+	report reporter
+
+	timeout       time.Duration
+	timeoutResult Result
+}
+
+func newCheck(name string, report reporter, options []CheckOption) check {
+	check := check{
+		name:          name,
+		report:        report,
+		timeout:       DefaultCheckTimeout,
+		timeoutResult: Failure,
+	}
+
+	for _, option := range options {
+		option(&check)
+	}
+
+	return check
+}
+
+func (check check) run(ctx context.Context) CheckResult {
+	if check.timeout > 0 {
+		var cancel context.CancelFunc
+
+		ctx, cancel = context.WithTimeout(ctx, check.timeout)
+		defer cancel()
+	}
+
+	done := make(chan Report, 1)
+
+	started := time.Now()
+
+	go func() {
+		done <- check.report(ctx)
+	}()
+
+	select {
+	case report := <-done:
+		result, err := report.Unwrap()
+		if result.Validate() != nil {
+			result, err = Failure, ErrUnsupportedResult
+		}
+
+		return CheckResult{
+			Name:      check.name,
+			Result:    result,
+			Err:       err,
+			Message:   report.Message,
+			Details:   report.Details,
+			Component: report.Component,
+			Duration:  time.Since(started),
+		}
+	case <-ctx.Done():
+		err := ctx.Err()
+		if errors.Is(err, context.DeadlineExceeded) {
+			err = ErrCheckTimeout
+		}
+
+		return CheckResult{
+			Name:     check.name,
+			Result:   check.timeoutResult,
+			Err:      err,
+			Duration: time.Since(started),
+		}
+	}
+}
+
+type checks struct {
+	mu   sync.RWMutex
+	list []check
+}
+
+func (checks *checks) register(check check) {
+	checks.mu.Lock()
+	defer checks.mu.Unlock()
+
+	for i := range checks.list {
+		if checks.list[i].name == check.name {
+			checks.list[i] = check
+
+			return
+		}
+	}
+
+	checks.list = append(checks.list, check)
+}
+
+func (checks *checks) snapshot() []check {
+	checks.mu.RLock()
+	defer checks.mu.RUnlock()
+
+	return append([]check(nil), checks.list...)
+}
+
+func (checks *checks) run(ctx context.Context) CheckResults {
+	list := checks.snapshot()
+
+	if excluded := excludedChecks(ctx); len(excluded) > 0 {
+		included := list[:0]
+
+		for _, check := range list {
+			if !excluded[check.name] {
+				included = append(included, check)
+			}
+		}
+
+		list = included
+	}
+
+	results := make(CheckResults, len(list))
+
+	var wg sync.WaitGroup
+
+	wg.Add(len(list))
+
+	for i := range list {
+		go func(i int) {
+			defer wg.Done()
+
+			results[i] = list[i].run(ctx)
+		}(i)
+	}
+
+	wg.Wait()
+
+	return results
+}
+
+func (checks *checks) runOne(ctx context.Context, name string) (CheckResult, bool) {
+	for _, check := range checks.snapshot() {
+		if check.name == name {
+			return check.run(ctx), true
+		}
+	}
+
+	return CheckResult{}, false
+}
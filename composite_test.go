@@ -0,0 +1,333 @@
+package probes
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testProbe(result Result, err error) ProbeFunc {
+	return func(context.Context) (Result, error) {
+		return result, err
+	}
+}
+
+func assertCheckResult(t *testing.T, expected, actual CheckResult) {
+	t.Helper()
+
+	assert.Equal(t, expected.Name, actual.Name)
+	assert.Equal(t, expected.Result, actual.Result)
+	assert.Equal(t, expected.Err, actual.Err)
+}
+
+func TestNewCompositeProbes(t *testing.T) {
+	t.Parallel()
+
+	// Arrange.
+	probes := NewCompositeProbes()
+
+	// Act.
+	liveness, livenessErr := probes.Liveness(context.Background())
+	readiness, readinessErr := probes.Readiness(context.Background())
+	startup, startupErr := probes.Startup(context.Background())
+
+	// Assert.
+	assert.Equal(t, Success, liveness)
+	assert.NoError(t, livenessErr)
+
+	assert.Equal(t, Success, readiness)
+	assert.NoError(t, readinessErr)
+
+	assert.Equal(t, Success, startup)
+	assert.NoError(t, startupErr)
+}
+
+func TestCompositeProbes_Readiness(t *testing.T) {
+	t.Parallel()
+
+	// Arrange.
+	tests := []struct {
+		name           string
+		probes         *CompositeProbes
+		expectedResult Result
+		expectedErr    string
+	}{
+		{
+			name: "Все проверки успешны",
+			probes: NewCompositeProbes().
+				RegisterReadiness("postgres", testProbe(Success, nil)).
+				RegisterReadiness("redis", testProbe(Success, nil)),
+			expectedResult: Success,
+			expectedErr:    "",
+		},
+		{
+			name: `Одна из проверок вернула "Warning"`,
+			probes: NewCompositeProbes().
+				RegisterReadiness("postgres", testProbe(Success, nil)).
+				RegisterReadiness("redis", testProbe(Warning, errDummyProbe)),
+			expectedResult: Warning,
+			expectedErr:    "redis: probes: dummy error",
+		},
+		{
+			name: `Одна из проверок вернула "Failure"`,
+			probes: NewCompositeProbes().
+				RegisterReadiness("postgres", testProbe(Failure, nil)).
+				RegisterReadiness("redis", testProbe(Warning, errDummyProbe)),
+			expectedResult: Failure,
+			expectedErr:    "postgres: failure; redis: probes: dummy error",
+		},
+		{
+			name: "Проверка вернула неподдерживаемый результат",
+			probes: NewCompositeProbes().
+				RegisterReadiness("postgres", testProbe(100, nil)),
+			expectedResult: Failure,
+			expectedErr:    "postgres: probes: unsupported result",
+		},
+		{
+			name: "Повторная регистрация заменяет проверку",
+			probes: NewCompositeProbes().
+				RegisterReadiness("postgres", testProbe(Failure, nil)).
+				RegisterReadiness("postgres", testProbe(Success, nil)),
+			expectedResult: Success,
+			expectedErr:    "",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Act.
+			result, err := test.probes.Readiness(context.Background())
+
+			// Assert.
+			assert.Equal(t, test.expectedResult, result)
+
+			if test.expectedErr == "" {
+				assert.NoError(t, err)
+
+				return
+			}
+
+			assert.EqualError(t, err, test.expectedErr)
+		})
+	}
+}
+
+func TestCompositeProbes_LivenessChecks(t *testing.T) {
+	t.Parallel()
+
+	// Arrange.
+	probes := NewCompositeProbes().
+		RegisterLiveness("deadlock", testProbe(Success, nil)).
+		RegisterLiveness("memory", testProbe(Warning, errDummyProbe)).
+		RegisterReadiness("postgres", testProbe(Failure, nil))
+
+	// Act.
+	results := probes.LivenessChecks(context.Background())
+
+	// Assert.
+	require.Len(t, results, 2)
+
+	assertCheckResult(t, CheckResult{Name: "deadlock", Result: Success}, results[0])
+	assertCheckResult(t, CheckResult{Name: "memory", Result: Warning, Err: errDummyProbe}, results[1])
+}
+
+func TestCompositeProbes_ReadinessChecks_Report(t *testing.T) {
+	t.Parallel()
+
+	// Arrange.
+	probes := NewCompositeProbes().
+		RegisterReadiness("postgres", ReportFunc(func(context.Context) Report {
+			return Report{
+				Result:    Warning,
+				Message:   "pool saturated",
+				Details:   map[string]interface{}{"in_use": 10},
+				Component: Component{Type: "datastore"},
+			}
+		}))
+
+	// Act.
+	results := probes.ReadinessChecks(context.Background())
+
+	// Assert.
+	require.Len(t, results, 1)
+
+	assert.Equal(t, Warning, results[0].Result)
+	assert.EqualError(t, results[0].Err, "pool saturated")
+	assert.Equal(t, "pool saturated", results[0].Message)
+	assert.Equal(t, map[string]interface{}{"in_use": 10}, results[0].Details)
+	assert.Equal(t, Component{Type: "datastore"}, results[0].Component)
+}
+
+func TestCompositeProbes_StartupChecks(t *testing.T) {
+	t.Parallel()
+
+	// Arrange.
+	probes := NewCompositeProbes().
+		RegisterStartup("migrations", testProbe(Failure, errDummyProbe))
+
+	// Act.
+	result, err := probes.StartupChecks(context.Background()).Unwrap()
+
+	// Assert.
+	assert.Equal(t, Failure, result)
+
+	var checksErr *ChecksError
+	require.True(t, errors.As(err, &checksErr))
+
+	require.Len(t, checksErr.Checks, 1)
+
+	assertCheckResult(t, CheckResult{Name: "migrations", Result: Failure, Err: errDummyProbe}, checksErr.Checks[0])
+}
+
+func testBlockingProbe(context.Context) (Result, error) {
+	select {}
+}
+
+func TestCompositeProbes_ReadinessChecks(t *testing.T) {
+	t.Parallel()
+
+	// Arrange.
+	probes := NewCompositeProbes().
+		RegisterReadiness("postgres", ProbeFunc(testBlockingProbe), WithTimeout(50*time.Millisecond)).
+		RegisterReadiness("kafka", ProbeFunc(testBlockingProbe), WithTimeout(50*time.Millisecond), WithTimeoutResult(Warning)).
+		RegisterReadiness("redis", ProbeFunc(func(ctx context.Context) (Result, error) {
+			<-ctx.Done()
+
+			return Failure, ctx.Err()
+		}), WithTimeout(50*time.Millisecond))
+
+	// Act.
+	started := time.Now()
+
+	results := probes.ReadinessChecks(context.Background())
+
+	elapsed := time.Since(started)
+
+	// Assert.
+	require.Len(t, results, 3)
+
+	assert.Less(t, elapsed, 500*time.Millisecond)
+
+	assertCheckResult(t, CheckResult{Name: "postgres", Result: Failure, Err: ErrCheckTimeout}, results[0])
+	assertCheckResult(t, CheckResult{Name: "kafka", Result: Warning, Err: ErrCheckTimeout}, results[1])
+
+	assert.GreaterOrEqual(t, results[0].Duration, 50*time.Millisecond)
+
+	assert.Equal(t, "redis", results[2].Name)
+	assert.Equal(t, Failure, results[2].Result)
+	assert.Error(t, results[2].Err)
+}
+
+func TestCompositeProbes_Readiness_ContextDeadline(t *testing.T) {
+	t.Parallel()
+
+	// Arrange.
+	probes := NewCompositeProbes().
+		RegisterReadiness("postgres", ProbeFunc(testBlockingProbe), WithTimeout(time.Hour))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	// Act.
+	result, err := probes.Readiness(ctx)
+
+	// Assert.
+	assert.Equal(t, Failure, result)
+	assert.EqualError(t, err, "postgres: probes: check timed out")
+}
+
+func TestNewCheck_Timeout(t *testing.T) {
+	t.Parallel()
+
+	// Arrange.
+	tests := []struct {
+		name            string
+		options         []CheckOption
+		expectedTimeout time.Duration
+	}{
+		{
+			name:            "Время по умолчанию",
+			options:         nil,
+			expectedTimeout: DefaultCheckTimeout,
+		},
+		{
+			name:            "Ограничение отключено",
+			options:         []CheckOption{WithTimeout(0)},
+			expectedTimeout: 0,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Act.
+			check := newCheck("postgres", readinessReporter(testProbe(Success, nil)), test.options)
+
+			// Assert.
+			assert.Equal(t, test.expectedTimeout, check.timeout)
+		})
+	}
+}
+
+func TestWithExcludedChecks(t *testing.T) {
+	t.Parallel()
+
+	// Arrange.
+	probes := NewCompositeProbes().
+		RegisterReadiness("postgres", testProbe(Success, nil)).
+		RegisterReadiness("redis", testProbe(Failure, nil)).
+		RegisterReadiness("kafka", testProbe(Failure, nil))
+
+	ctx := WithExcludedChecks(context.Background(), "redis")
+	ctx = WithExcludedChecks(ctx, "kafka", "unknown")
+
+	// Act.
+	results := probes.ReadinessChecks(ctx)
+
+	// Assert.
+	require.Len(t, results, 1)
+
+	assert.Equal(t, "postgres", results[0].Name)
+	assert.Equal(t, Success, results.Result())
+}
+
+func TestCompositeProbes_ReadinessCheck(t *testing.T) {
+	t.Parallel()
+
+	// Arrange.
+	probes := NewCompositeProbes().
+		RegisterReadiness("postgres", testProbe(Success, nil)).
+		RegisterReadiness("redis", testProbe(Failure, errDummyProbe))
+
+	tests := []struct {
+		name          string
+		check         string
+		expectedFound bool
+		expected      CheckResult
+	}{
+		{
+			name:          "Зарегистрированная проверка",
+			check:         "redis",
+			expectedFound: true,
+			expected:      CheckResult{Name: "redis", Result: Failure, Err: errDummyProbe},
+		},
+		{
+			name:          "Незарегистрированная проверка",
+			check:         "kafka",
+			expectedFound: false,
+			expected:      CheckResult{},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Act.
+			actual, found := probes.ReadinessCheck(context.Background(), test.check)
+
+			// Assert.
+			assert.Equal(t, test.expectedFound, found)
+
+			assertCheckResult(t, test.expected, actual)
+		})
+	}
+}
@@ -0,0 +1,346 @@
+package probes
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultDiskSoftFree содержит долю свободного места
+	// и inode по умолчанию, ниже которой проверка диска
+	// возвращает Warning.
+	DefaultDiskSoftFree = 0.10
+
+	// DefaultDiskHardFree содержит долю свободного места
+	// и inode по умолчанию, ниже которой проверка диска
+	// возвращает Failure.
+	DefaultDiskHardFree = 0.05
+
+	// DefaultDiskTimeout содержит время по умолчанию,
+	// отведённое DiskChecker на проверку каталога,
+	// совпадающее с timeoutSeconds пробы Kubernetes по
+	// умолчанию.
+	DefaultDiskTimeout = time.Second
+)
+
+// ErrDiskUnsupported указывает, что статистика файловой
+// системы недоступна на текущей платформе.
+var ErrDiskUnsupported = errors.New("probes: disk statistics are not supported on this platform")
+
+// DiskOption настраивает DiskChecker.
+type DiskOption func(*DiskChecker)
+
+// WithDiskFreeSpace задаёт доли свободного места, ниже
+// которых проба возвращает Warning (soft) и
+// Failure (hard).
+//
+// По умолчанию используются DefaultDiskSoftFree и
+// DefaultDiskHardFree. Нулевое значение отключает порог,
+// а мягкий порог ниже жёсткого приравнивается к нему.
+func WithDiskFreeSpace(soft, hard float64) DiskOption {
+	return func(checker *DiskChecker) {
+		checker.space = newDiskThreshold(soft, hard)
+	}
+}
+
+// WithDiskFreeInodes задаёт доли свободных inode, ниже
+// которых проба возвращает Warning (soft) и
+// Failure (hard).
+//
+//	Смотри WithDiskFreeSpace
+func WithDiskFreeInodes(soft, hard float64) DiskOption {
+	return func(checker *DiskChecker) {
+		checker.inodes = newDiskThreshold(soft, hard)
+	}
+}
+
+// WithDiskWriteProbe включает проверку записи: в каждом
+// каталоге создаётся, записывается и удаляется
+// временный файл. Ошибка записи возвращается как Failure.
+func WithDiskWriteProbe() DiskOption {
+	return func(checker *DiskChecker) {
+		checker.write = true
+	}
+}
+
+// WithDiskTimeout задаёт время, отведённое на проверку
+// каталога, включая проверку записи.
+//
+// По умолчанию используется DefaultDiskTimeout.
+// Нулевое значение отключает ограничение, и проба
+// ограничена только сроком ctx.
+func WithDiskTimeout(timeout time.Duration) DiskOption {
+	return func(checker *DiskChecker) {
+		checker.timeout = timeout
+	}
+}
+
+// DiskChecker реализует Liveness-, Readiness- и
+// Startup-пробы свободного места и inode файловых
+// систем, на которых расположены каталоги, например тома
+// с логами и кэшем.
+//
+// Статистика файловой системы получается через statfs
+// в пределах WithDiskTimeout: зависшая файловая система,
+// например недоступный сетевой том, возвращает Failure с
+// ошибкой контекста, а повторные проверки ожидают уже
+// начатый системный вызов вместо нового. Результат по всем каталогам
+// объединяется Aggregate, а свободное место и inode
+// каждого каталога передаются в Report.Details. На
+// платформах без statfs проба возвращает Unknown с
+// ошибкой ErrDiskUnsupported.
+//
+// Для инициализации необходимо использовать метод
+// NewDiskChecker.
+type DiskChecker struct {
+	paths  []string
+	space  diskThreshold
+	inodes diskThreshold
+	write  bool
+
+	timeout time.Duration
+
+	mu      sync.Mutex
+	pending map[string]*diskInspection
+}
+
+// NewDiskChecker инициализирует пробу файловых систем
+// каталогов paths.
+func NewDiskChecker(paths []string, options ...DiskOption) *DiskChecker {
+	checker := &DiskChecker{
+		paths:  paths,
+		space:  diskThreshold{soft: DefaultDiskSoftFree, hard: DefaultDiskHardFree},
+		inodes: diskThreshold{soft: DefaultDiskSoftFree, hard: DefaultDiskHardFree},
+
+		timeout: DefaultDiskTimeout,
+		pending: make(map[string]*diskInspection),
+	}
+
+	for _, option := range options {
+		option(checker)
+	}
+
+	return checker
+}
+
+func (checker *DiskChecker) Liveness(ctx context.Context) (Result, error) {
+	return checker.report(ctx).Unwrap()
+}
+
+func (checker *DiskChecker) Readiness(ctx context.Context) (Result, error) {
+	return checker.report(ctx).Unwrap()
+}
+
+func (checker *DiskChecker) Startup(ctx context.Context) (Result, error) {
+	return checker.report(ctx).Unwrap()
+}
+
+// LivenessReport возвращает отчёт Liveness-пробы со
+// свободным местом и inode каждого каталога.
+func (checker *DiskChecker) LivenessReport(ctx context.Context) Report {
+	return checker.report(ctx)
+}
+
+// ReadinessReport возвращает отчёт Readiness-пробы.
+//
+//	Смотри LivenessReport
+func (checker *DiskChecker) ReadinessReport(ctx context.Context) Report {
+	return checker.report(ctx)
+}
+
+// StartupReport возвращает отчёт Startup-пробы.
+//
+//	Смотри LivenessReport
+func (checker *DiskChecker) StartupReport(ctx context.Context) Report {
+	return checker.report(ctx)
+}
+
+func (checker *DiskChecker) report(ctx context.Context) Report {
+	if checker.timeout > 0 {
+		var cancel context.CancelFunc
+
+		ctx, cancel = context.WithTimeout(ctx, checker.timeout)
+		defer cancel()
+	}
+
+	report := Report{Details: make(map[string]interface{}, len(checker.paths)), Component: Component{Type: "system"}}
+
+	var (
+		results  []Result
+		messages []string
+	)
+
+	for _, path := range checker.paths {
+		result, err := checker.check(ctx, path, report.Details)
+
+		results = append(results, result)
+
+		if err != nil {
+			messages = append(messages, path+": "+err.Error())
+		}
+	}
+
+	report.Result = Aggregate(results...)
+
+	if len(messages) > 0 {
+		report.Err = errors.New(strings.Join(messages, "; "))
+	}
+
+	return report
+}
+
+type diskThreshold struct {
+	soft float64
+	hard float64
+}
+
+func newDiskThreshold(soft, hard float64) diskThreshold {
+	if soft < hard {
+		soft = hard
+	}
+
+	return diskThreshold{soft: soft, hard: hard}
+}
+
+// check возвращает результат сравнения доли free/total
+// с порогами. Пустая файловая система, например без
+// учёта inode, считается исправной.
+func (threshold diskThreshold) check(kind string, free, total uint64) (Result, error) {
+	if total == 0 {
+		return Success, nil
+	}
+
+	ratio := float64(free) / float64(total)
+
+	switch {
+	case ratio < threshold.hard:
+		return Failure, fmt.Errorf("%.1f%% free %s below %.1f%%", ratio*100, kind, threshold.hard*100)
+	case ratio < threshold.soft:
+		return Warning, fmt.Errorf("%.1f%% free %s below %.1f%%", ratio*100, kind, threshold.soft*100)
+	default:
+		return Success, nil
+	}
+}
+
+type diskUsage struct {
+	totalBytes  uint64
+	freeBytes   uint64
+	totalInodes uint64
+	freeInodes  uint64
+}
+
+func (checker *DiskChecker) check(ctx context.Context, path string, details map[string]interface{}) (Result, error) {
+	usage, err := checker.inspect(ctx, path)
+	if errors.Is(err, ErrDiskUnsupported) {
+		return Unknown, err
+	}
+
+	if usage != nil {
+		details[path] = map[string]uint64{
+			"total_bytes":  usage.totalBytes,
+			"free_bytes":   usage.freeBytes,
+			"total_inodes": usage.totalInodes,
+			"free_inodes":  usage.freeInodes,
+		}
+	}
+
+	if err != nil {
+		return Failure, err
+	}
+
+	space, spaceErr := checker.space.check("space", usage.freeBytes, usage.totalBytes)
+	inodes, inodesErr := checker.inodes.check("inodes", usage.freeInodes, usage.totalInodes)
+
+	switch {
+	case spaceErr != nil && inodesErr != nil:
+		return Aggregate(space, inodes), fmt.Errorf("%v, %v", spaceErr, inodesErr)
+	case spaceErr != nil:
+		return space, spaceErr
+	default:
+		return inodes, inodesErr
+	}
+}
+
+// diskInspection содержит результат проверки каталога,
+// выполняемой в отдельной горутине.
+type diskInspection struct {
+	done  chan struct{}
+	usage *diskUsage
+	err   error
+}
+
+// inspect получает статистику файловой системы и
+// выполняет проверку записи в отдельной горутине, чтобы
+// не блокировать пробу дольше срока ctx. Пока горутина
+// не завершилась, новые проверки каталога ожидают её
+// результат, поэтому зависшая файловая система
+// удерживает не более одной горутины на каталог.
+func (checker *DiskChecker) inspect(ctx context.Context, path string) (*diskUsage, error) {
+	checker.mu.Lock()
+
+	inspection, found := checker.pending[path]
+	if !found {
+		inspection = &diskInspection{done: make(chan struct{})}
+		checker.pending[path] = inspection
+
+		go checker.run(path, inspection)
+	}
+
+	checker.mu.Unlock()
+
+	select {
+	case <-inspection.done:
+		return inspection.usage, inspection.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (checker *DiskChecker) run(path string, inspection *diskInspection) {
+	defer func() {
+		checker.mu.Lock()
+		delete(checker.pending, path)
+		checker.mu.Unlock()
+
+		close(inspection.done)
+	}()
+
+	usage, err := statfs(path)
+	if err != nil {
+		inspection.err = err
+
+		return
+	}
+
+	inspection.usage = &usage
+
+	if checker.write {
+		if err := writeProbe(path); err != nil {
+			inspection.err = fmt.Errorf("write: %w", err)
+		}
+	}
+}
+
+func writeProbe(dir string) error {
+	file, err := os.CreateTemp(dir, ".probes-*")
+	if err != nil {
+		return err
+	}
+
+	_, writeErr := file.Write([]byte("probes"))
+	closeErr := file.Close()
+	removeErr := os.Remove(file.Name())
+
+	for _, err := range []error{writeErr, closeErr, removeErr} {
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,20 @@
+//go:build darwin || freebsd
+
+package probes
+
+import "syscall"
+
+func statfs(path string) (diskUsage, error) {
+	var stat syscall.Statfs_t
+
+	if err := syscall.Statfs(path, &stat); err != nil {
+		return diskUsage{}, err
+	}
+
+	return diskUsage{
+		totalBytes:  uint64(stat.Blocks) * uint64(stat.Bsize),
+		freeBytes:   uint64(stat.Bavail) * uint64(stat.Bsize),
+		totalInodes: uint64(stat.Files),
+		freeInodes:  uint64(stat.Ffree),
+	}, nil
+}
@@ -0,0 +1,26 @@
+package probes
+
+import "syscall"
+
+// statfs считает размер по Frsize: Bsize в Linux
+// содержит оптимальный размер блока ввода-вывода, а
+// Blocks и Bavail измеряются во фрагментах.
+func statfs(path string) (diskUsage, error) {
+	var stat syscall.Statfs_t
+
+	if err := syscall.Statfs(path, &stat); err != nil {
+		return diskUsage{}, err
+	}
+
+	size := uint64(stat.Frsize)
+	if size == 0 {
+		size = uint64(stat.Bsize)
+	}
+
+	return diskUsage{
+		totalBytes:  uint64(stat.Blocks) * size,
+		freeBytes:   uint64(stat.Bavail) * size,
+		totalInodes: uint64(stat.Files),
+		freeInodes:  uint64(stat.Ffree),
+	}, nil
+}
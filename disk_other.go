@@ -0,0 +1,7 @@
+//go:build !(linux || darwin || freebsd)
+
+package probes
+
+func statfs(string) (diskUsage, error) {
+	return diskUsage{}, ErrDiskUnsupported
+}
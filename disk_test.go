@@ -0,0 +1,203 @@
+//go:build linux || darwin || freebsd
+
+package probes
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiskChecker_Liveness(t *testing.T) {
+	t.Parallel()
+
+	// Arrange.
+	dir := t.TempDir()
+
+	file := filepath.Join(dir, "file")
+	require.NoError(t, os.WriteFile(file, nil, 0o600))
+
+	tests := []struct {
+		name           string
+		paths          []string
+		options        []DiskOption
+		expectedResult Result
+		expectedErr    string
+	}{
+		{
+			name:           "Без порогов",
+			paths:          []string{dir},
+			options:        []DiskOption{WithDiskFreeSpace(0, 0), WithDiskFreeInodes(0, 0), WithDiskWriteProbe()},
+			expectedResult: Success,
+		},
+		{
+			name:           "Ниже мягкого порога",
+			paths:          []string{dir},
+			options:        []DiskOption{WithDiskFreeSpace(1.01, 0), WithDiskFreeInodes(0, 0)},
+			expectedResult: Warning,
+			expectedErr:    "free space below 101.0%",
+		},
+		{
+			name:           "Ниже жёсткого порога",
+			paths:          []string{dir},
+			options:        []DiskOption{WithDiskFreeSpace(1.01, 1.01), WithDiskFreeInodes(0, 0)},
+			expectedResult: Failure,
+			expectedErr:    "free space below 101.0%",
+		},
+		{
+			name:           "Мягкий порог ниже жёсткого",
+			paths:          []string{dir},
+			options:        []DiskOption{WithDiskFreeSpace(0, 1.01), WithDiskFreeInodes(0, 0)},
+			expectedResult: Failure,
+			expectedErr:    "free space below 101.0%",
+		},
+		{
+			name:           "Каталог не существует",
+			paths:          []string{dir, filepath.Join(dir, "missing")},
+			expectedResult: Failure,
+			expectedErr:    filepath.Join(dir, "missing") + ": no such file or directory",
+		},
+		{
+			name:           "Ошибка записи",
+			paths:          []string{file},
+			options:        []DiskOption{WithDiskFreeSpace(0, 0), WithDiskFreeInodes(0, 0), WithDiskWriteProbe()},
+			expectedResult: Failure,
+			expectedErr:    file + ": write: ",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			probe := NewDiskChecker(test.paths, test.options...)
+
+			// Act.
+			result, err := probe.Liveness(context.Background())
+
+			// Assert.
+			assert.Equal(t, test.expectedResult, result)
+
+			if test.expectedErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, test.expectedErr)
+			}
+		})
+	}
+}
+
+func TestDiskChecker_LivenessReport(t *testing.T) {
+	t.Parallel()
+
+	// Arrange.
+	dir := t.TempDir()
+
+	// Act.
+	report := NewDiskChecker([]string{dir}, WithDiskFreeSpace(0, 0), WithDiskFreeInodes(0, 0)).
+		LivenessReport(context.Background())
+
+	// Assert.
+	require.Contains(t, report.Details, dir)
+
+	usage, ok := report.Details[dir].(map[string]uint64)
+	require.True(t, ok)
+
+	assert.Positive(t, usage["total_bytes"])
+	assert.LessOrEqual(t, usage["free_bytes"], usage["total_bytes"])
+}
+
+func TestDiskChecker_LivenessReport_Canceled(t *testing.T) {
+	t.Parallel()
+
+	// Arrange.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// Act.
+	report := NewDiskChecker([]string{t.TempDir()}).LivenessReport(ctx)
+
+	// Assert.
+	assert.Equal(t, Failure, report.Result)
+	assert.ErrorContains(t, report.Err, context.Canceled.Error())
+}
+
+func TestNewDiskThreshold(t *testing.T) {
+	t.Parallel()
+
+	// Arrange.
+	tests := []struct {
+		name              string
+		soft              float64
+		hard              float64
+		expectedThreshold diskThreshold
+	}{
+		{
+			name:              "Мягкий порог выше жёсткого",
+			soft:              0.10,
+			hard:              0.05,
+			expectedThreshold: diskThreshold{soft: 0.10, hard: 0.05},
+		},
+		{
+			name:              "Мягкий порог ниже жёсткого",
+			soft:              0.05,
+			hard:              0.10,
+			expectedThreshold: diskThreshold{soft: 0.10, hard: 0.10},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Act.
+			actualThreshold := newDiskThreshold(test.soft, test.hard)
+
+			// Assert.
+			assert.Equal(t, test.expectedThreshold, actualThreshold)
+		})
+	}
+}
+
+func TestDiskChecker_Composite(t *testing.T) {
+	t.Parallel()
+
+	// Arrange.
+	checker := NewDiskChecker([]string{t.TempDir()}, WithDiskFreeSpace(0, 0), WithDiskFreeInodes(0, 0))
+
+	probes := NewCompositeProbes().
+		RegisterLiveness("volumes", checker).
+		RegisterReadiness("volumes", checker).
+		RegisterStartup("volumes", checker)
+
+	// Act.
+	liveness, livenessErr := probes.Liveness(context.Background())
+	readiness, readinessErr := probes.Readiness(context.Background())
+	startup, startupErr := probes.Startup(context.Background())
+
+	// Assert.
+	assert.Equal(t, []Result{Success, Success, Success}, []Result{liveness, readiness, startup})
+	assert.NoError(t, livenessErr)
+	assert.NoError(t, readinessErr)
+	assert.NoError(t, startupErr)
+}
+
+func TestDiskChecker_LivenessReport_Timeout(t *testing.T) {
+	t.Parallel()
+
+	// Arrange.
+	dir := t.TempDir()
+
+	probe := NewDiskChecker([]string{dir}, WithDiskTimeout(10*time.Millisecond))
+
+	// Зависший statfs предыдущей проверки.
+	hung := &diskInspection{done: make(chan struct{})}
+	probe.pending[dir] = hung
+
+	// Act.
+	report := probe.LivenessReport(context.Background())
+
+	// Assert.
+	assert.Equal(t, Failure, report.Result)
+	assert.ErrorContains(t, report.Err, context.DeadlineExceeded.Error())
+	assert.Same(t, hung, probe.pending[dir])
+}
@@ -1,6 +1,11 @@
 package probes
 
 import (
+	"context"
+	"net/url"
+	"os"
+	"strings"
+
 	"github.com/gofiber/fiber/v2"
 )
 
@@ -10,8 +15,10 @@ import (
 // После вызова метода следует вызвать метод
 // FiberServer.Probes, который выполнит инициализацию
 // REST-эндпоинтов.
-func Fiber(app *fiber.App) *FiberServer {
-	return &FiberServer{app: app}
+//
+//	Смотри ServerOption
+func Fiber(app *fiber.App, options ...ServerOption) *FiberServer {
+	return &FiberServer{app: app, config: newServerConfig(options)}
 }
 
 // FiberServer содержит REST-эндпоинты для проб
@@ -20,7 +27,8 @@ func Fiber(app *fiber.App) *FiberServer {
 // Для инициализации сервера необходим сначала
 // вызвать метод Fiber с необходимыми параметрами.
 type FiberServer struct {
-	app *fiber.App
+	app    *fiber.App
+	config serverConfig
 
 	probes Probes
 }
@@ -28,25 +36,149 @@ type FiberServer struct {
 // Probes инициализирует REST-эндпоинты для Liveness-,
 // Readiness- и Startup-проб Kubernetes.
 //
+// По умолчанию:
+//
 //	Liveness-эндпоинт доступен по пути /liveness
 //	Readiness-эндпоинт доступен по пути /readiness
 //	Startup-эндпоинт доступен по пути /startup
-func (server *FiberServer) Probes(probes Probes) *FiberServer {
-	server.app.Get(DefaultLivenessPath, NewFiberLiveness(probes).Liveness)
-	server.app.Get(DefaultReadinessPath, NewFiberReadiness(probes).Readiness)
-	server.app.Get(DefaultStartupPath, NewFiberStartup(probes).Startup)
+//
+// Пути, HTTP-методы и набор эндпоинтов настраиваются
+// параметрами ServerOption метода Fiber.
+//
+// Именованные проверки CompositeProbes доступны по
+// путям вида /readiness/postgres.
+//
+// Параметры options применяются ко всем обработчикам.
+func (server *FiberServer) Probes(probes Probes, options ...HandlerOption) *FiberServer {
+	if !server.config.livenessDisabled {
+		liveness := NewFiberLiveness(probes, options...)
+		server.handle(server.config.livenessPath, liveness.Liveness, liveness.Check)
+	}
+
+	if !server.config.readinessDisabled {
+		readiness := NewFiberReadiness(probes, options...)
+		server.handle(server.config.readinessPath, readiness.Readiness, readiness.Check)
+	}
+
+	if !server.config.startupDisabled {
+		startup := NewFiberStartup(probes, options...)
+		server.handle(server.config.startupPath, startup.Startup, startup.Check)
+	}
 
 	server.probes = probes
 
 	return server
 }
 
+// Override инициализирует административный эндпоинт
+// переопределения Readiness-пробы, доступный по пути
+// /override:
+//
+//	GET    – текущее переопределение;
+//	PUT    – задать переопределение из JSON-тела
+//	         {"result": "failure", "reason": "profiling", "ttl": "15m"};
+//	DELETE – снять переопределение.
+//
+// Запросы должны содержать заголовок
+// Authorization: Bearer <token>, иначе эндпоинт отвечает
+// HTTP 401. Пустой token запрещает любые запросы. Тело
+// больше 4 КиБ отклоняется с HTTP 400 и ошибкой
+// ErrOverrideTooLarge.
+//
+// Сам Override необходимо передать в FiberServer.Probes
+// в качестве Readiness-пробы.
+//
+//	Смотри WithOverridePath
+func (server *FiberServer) Override(override *Override, token string) *FiberServer {
+	handler := func(ctx *fiber.Ctx) error {
+		// Тело передаётся без распаковки Content-Encoding,
+		// как в HTTPServer.Override, а его размер
+		// проверяется после авторизации.
+		body := ctx.Request().Body()
+
+		return sendFiber(ctx, override.serve(token, ctx.Method(), ctx.Get(fiber.HeaderAuthorization), body))
+	}
+
+	for _, method := range []string{fiber.MethodGet, fiber.MethodPut, fiber.MethodDelete} {
+		server.app.Add(method, server.config.path(server.config.overridePath), handler)
+	}
+
+	return server
+}
+
+// Metrics инициализирует эндпоинт метрик Prometheus,
+// доступный по пути /metrics.
+//
+// Чтобы метрики обновлялись, тот же сборщик необходимо
+// передать в FiberServer.Probes параметром WithMetrics.
+//
+//	Смотри WithMetricsPath
+func (server *FiberServer) Metrics(metrics *Metrics) *FiberServer {
+	server.app.Get(server.config.path(server.config.metricsPath), func(ctx *fiber.Ctx) error {
+		ctx.Set(fiber.HeaderContentType, MetricsContentType)
+
+		_, err := metrics.WriteTo(ctx)
+
+		return err
+	})
+
+	return server
+}
+
 // Start запускает REST-сервер с пробами Kubernetes
 // по указанному адресу.
 func (server *FiberServer) Start(address string) error {
 	return server.app.Listen(address)
 }
 
+// Listen запускает REST-сервер с пробами Kubernetes
+// по адресу, заданному WithAddress.
+//
+// По умолчанию используется DefaultServerAddress.
+func (server *FiberServer) Listen() error {
+	return server.Start(server.config.address)
+}
+
+// Shutdown корректно останавливает REST-сервер,
+// дожидаясь завершения активных запросов, но не дольше,
+// чем до отмены ctx.
+func (server *FiberServer) Shutdown(ctx context.Context) error {
+	done := make(chan error, 1)
+
+	go func() {
+		done <- server.app.Shutdown()
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// StartUnix запускает REST-сервер с пробами Kubernetes
+// на unix-сокете с правами доступа mode, не открывая
+// сетевой порт.
+//
+// Файл сокета, оставшийся от предыдущего запуска,
+// удаляется.
+func (server *FiberServer) StartUnix(path string, mode os.FileMode) error {
+	listener, err := listenUnix(path, mode)
+	if err != nil {
+		return err
+	}
+
+	return server.app.Listener(listener)
+}
+
+func (server *FiberServer) handle(path string, probe, check fiber.Handler) {
+	for _, method := range server.config.methods {
+		server.app.Add(method, server.config.path(path), probe)
+		server.app.Add(method, checkRoute(server.config.path(path)), check)
+	}
+}
+
 // DefaultFiberLiveness содержит инициализированный
 // HTTP-обработчик Liveness-запроса Kubernetes.
 //
@@ -62,7 +194,8 @@ var DefaultFiberLiveness = NewFiberLiveness(DefaultLiveness)
 // NewFiberLiveness.
 // Реализация по умолчанию – DefaultFiberLiveness.
 type FiberLiveness struct {
-	probe Liveness
+	probe  Liveness
+	config handlerConfig
 }
 
 // Liveness обрабатывает HTTP-запрос Liveness от
@@ -71,25 +204,34 @@ type FiberLiveness struct {
 // Если Liveness возвращает Success или Warning,
 // то обработчик возвращает HTTP 200 OK с опциональным
 // телом, если Failure – HTTP 500 Internal Server Error
-// с опциональным телом.
+// с опциональным телом. Коды ответа настраиваются
+// параметром WithStatusMapper.
+//
+// Тело ответа формирует Renderer, по умолчанию – текст
+// ошибки пробы.
 func (handler FiberLiveness) Liveness(ctx *fiber.Ctx) error {
-	result, err := handler.probe.Liveness(ctx.Context())
-
-	if result.IsSuccess() || result.IsWarning() {
-		ctx.Status(fiber.StatusOK)
-	}
-
-	if result.IsFailure() {
-		ctx.Status(fiber.StatusInternalServerError)
-	}
+	return serveFiber(ctx, handler.config, livenessReporter(handler.probe))
+}
 
-	return sendFiberError(ctx, err)
+// Check обрабатывает HTTP-запрос именованной проверки
+// Liveness-пробы, имя которой передаётся в параметре пути
+// :check.
+//
+// Коды ответа совпадают с FiberLiveness.Liveness. Если проба
+// не состоит из именованных проверок или проверка не
+// зарегистрирована, возвращается HTTP 404 Not Found.
+//
+//	Смотри CompositeProbes
+func (handler FiberLiveness) Check(ctx *fiber.Ctx) error {
+	return serveFiberCheck(ctx, handler.config, livenessCheckReporter(handler.probe))
 }
 
 // NewFiberLiveness инициализирует HTTP-обработчик
 // Liveness-запросов Kubernetes на Fiber.
-func NewFiberLiveness(probe Liveness) FiberLiveness {
-	return FiberLiveness{probe: probe}
+//
+//	Смотри HandlerOption
+func NewFiberLiveness(probe Liveness, options ...HandlerOption) FiberLiveness {
+	return FiberLiveness{probe: probe, config: newHandlerConfig(options)}
 }
 
 // DefaultFiberReadiness содержит инициализированный
@@ -107,7 +249,8 @@ var DefaultFiberReadiness = NewFiberReadiness(DefaultReadiness)
 // NewFiberReadiness.
 // Реализация по умолчанию – DefaultFiberReadiness.
 type FiberReadiness struct {
-	probe Readiness
+	probe  Readiness
+	config handlerConfig
 }
 
 // Readiness обрабатывает HTTP-запрос Readiness от
@@ -116,25 +259,34 @@ type FiberReadiness struct {
 // Если Readiness возвращает Success или Warning,
 // то обработчик возвращает HTTP 200 OK с опциональным
 // телом, если Failure – HTTP 500 Internal Server Error
-// с опциональным телом.
+// с опциональным телом. Коды ответа настраиваются
+// параметром WithStatusMapper.
+//
+// Тело ответа формирует Renderer, по умолчанию – текст
+// ошибки пробы.
 func (handler FiberReadiness) Readiness(ctx *fiber.Ctx) error {
-	result, err := handler.probe.Readiness(ctx.Context())
-
-	if result.IsSuccess() || result.IsWarning() {
-		ctx.Status(fiber.StatusOK)
-	}
-
-	if result.IsFailure() {
-		ctx.Status(fiber.StatusInternalServerError)
-	}
+	return serveFiber(ctx, handler.config, readinessReporter(handler.probe))
+}
 
-	return sendFiberError(ctx, err)
+// Check обрабатывает HTTP-запрос именованной проверки
+// Readiness-пробы, имя которой передаётся в параметре пути
+// :check.
+//
+// Коды ответа совпадают с FiberReadiness.Readiness. Если проба
+// не состоит из именованных проверок или проверка не
+// зарегистрирована, возвращается HTTP 404 Not Found.
+//
+//	Смотри CompositeProbes
+func (handler FiberReadiness) Check(ctx *fiber.Ctx) error {
+	return serveFiberCheck(ctx, handler.config, readinessCheckReporter(handler.probe))
 }
 
 // NewFiberReadiness инициализирует HTTP-обработчик
 // Readiness-запросов Kubernetes на Fiber.
-func NewFiberReadiness(probe Readiness) FiberReadiness {
-	return FiberReadiness{probe: probe}
+//
+//	Смотри HandlerOption
+func NewFiberReadiness(probe Readiness, options ...HandlerOption) FiberReadiness {
+	return FiberReadiness{probe: probe, config: newHandlerConfig(options)}
 }
 
 // DefaultFiberStartup содержит инициализированный
@@ -152,7 +304,8 @@ var DefaultFiberStartup = NewFiberStartup(DefaultStartup)
 // NewFiberStartup.
 // Реализация по умолчанию – DefaultFiberStartup.
 type FiberStartup struct {
-	probe Startup
+	probe  Startup
+	config handlerConfig
 }
 
 // Startup обрабатывает HTTP-запрос Startup от
@@ -161,31 +314,94 @@ type FiberStartup struct {
 // Если Startup возвращает Success или Warning,
 // то обработчик возвращает HTTP 200 OK с опциональным
 // телом, если Failure – HTTP 500 Internal Server Error
-// с опциональным телом.
+// с опциональным телом. Коды ответа настраиваются
+// параметром WithStatusMapper.
+//
+// Тело ответа формирует Renderer, по умолчанию – текст
+// ошибки пробы.
 func (handler FiberStartup) Startup(ctx *fiber.Ctx) error {
-	result, err := handler.probe.Startup(ctx.Context())
+	return serveFiber(ctx, handler.config, startupReporter(handler.probe))
+}
+
+// Check обрабатывает HTTP-запрос именованной проверки
+// Startup-пробы, имя которой передаётся в параметре пути
+// :check.
+//
+// Коды ответа совпадают с FiberStartup.Startup. Если проба
+// не состоит из именованных проверок или проверка не
+// зарегистрирована, возвращается HTTP 404 Not Found.
+//
+//	Смотри CompositeProbes
+func (handler FiberStartup) Check(ctx *fiber.Ctx) error {
+	return serveFiberCheck(ctx, handler.config, startupCheckReporter(handler.probe))
+}
 
-	if result.IsSuccess() || result.IsWarning() {
-		ctx.Status(fiber.StatusOK)
+// NewFiberStartup инициализирует HTTP-обработчик
+// Startup-запросов Kubernetes на Fiber.
+//
+//	Смотри HandlerOption
+func NewFiberStartup(probe Startup, options ...HandlerOption) FiberStartup {
+	return FiberStartup{probe: probe, config: newHandlerConfig(options)}
+}
+
+func fiberRequest(ctx *fiber.Ctx) request {
+	request := request{
+		accept:  ctx.Get(fiber.HeaderAccept),
+		verbose: ctx.Context().QueryArgs().Has(verboseParam),
 	}
 
-	if result.IsFailure() {
-		ctx.Status(fiber.StatusInternalServerError)
+	for _, exclude := range ctx.Context().QueryArgs().PeekMulti(excludeParam) {
+		request.exclude = append(request.exclude, strings.Split(string(exclude), ",")...)
 	}
 
-	return sendFiberError(ctx, err)
+	return request
 }
 
-// NewFiberStartup инициализирует HTTP-обработчик
-// Startup-запросов Kubernetes на Fiber.
-func NewFiberStartup(probe Startup) FiberStartup {
-	return FiberStartup{probe: probe}
+func serveFiber(ctx *fiber.Ctx, config handlerConfig, reporter reporter) error {
+	request := fiberRequest(ctx)
+
+	report := reporter(request.context(ctx.Context()))
+	config.observe(request, report)
+
+	response, err := config.respond(request, report)
+	if err != nil {
+		return err
+	}
+
+	return sendFiber(ctx, response)
 }
 
-func sendFiberError(ctx *fiber.Ctx, err error) error {
-	if err == nil {
-		return ctx.Send(nil)
+func serveFiberCheck(ctx *fiber.Ctx, config handlerConfig, reporter checkReporter) error {
+	request := fiberRequest(ctx)
+
+	name, err := url.PathUnescape(ctx.Params(checkParam))
+	if err != nil {
+		return sendFiber(ctx, unknownCheck(ctx.Params(checkParam)))
+	}
+
+	report, found := reporter(request.context(ctx.Context()), name)
+	if !found {
+		return sendFiber(ctx, unknownCheck(name))
+	}
+
+	response, err := config.respond(request, report)
+	if err != nil {
+		return err
+	}
+
+	return sendFiber(ctx, response)
+}
+
+func sendFiber(ctx *fiber.Ctx, response response) error {
+	ctx.Status(response.status)
+
+	if response.contentType != "" {
+		ctx.Set(fiber.HeaderContentType, response.contentType)
+	}
+
+	for header, value := range response.headers {
+		ctx.Set(header, value)
 	}
 
-	return ctx.SendString(err.Error())
+	return ctx.Send(response.body)
 }
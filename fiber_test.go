@@ -2,8 +2,13 @@ package probes
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"io"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -31,6 +36,7 @@ func TestFiber(t *testing.T) {
 
 	assert.Nil(t, server.probes)
 	assert.Equal(t, server.app, app)
+	assert.Equal(t, newServerConfig(nil), server.config)
 }
 
 func TestFiberServer_Probes(t *testing.T) {
@@ -51,6 +57,425 @@ func TestFiberServer_Probes(t *testing.T) {
 	assert.Equal(t, DefaultProbes, server.probes)
 }
 
+func TestFiberServer_Probes_JSON(t *testing.T) {
+	t.Parallel()
+
+	// Arrange.
+	app := fiber.New()
+
+	probes := NewCompositeProbes().
+		RegisterReadiness("postgres", testProbe(Success, nil)).
+		RegisterReadiness("redis", testProbe(Failure, dummyFiberError))
+
+	Fiber(app).Probes(probes, WithJSON())
+
+	// Act.
+	response, err := app.Test(httptest.NewRequest(fiber.MethodGet, DefaultReadinessPath, nil))
+
+	// Assert.
+	require.NoError(t, err)
+
+	assert.Equal(t, fiber.StatusInternalServerError, response.StatusCode)
+	assert.Equal(t, JSONContentType, response.Header.Get(fiber.HeaderContentType))
+
+	var body struct {
+		Status string `json:"status"`
+		Error  string `json:"error"`
+		Checks []struct {
+			Name   string `json:"name"`
+			Status string `json:"status"`
+		} `json:"checks"`
+	}
+
+	require.NoError(t, json.NewDecoder(response.Body).Decode(&body))
+
+	assert.Equal(t, "failure", body.Status)
+	assert.Equal(t, "redis: fiber: dummy error", body.Error)
+
+	require.Len(t, body.Checks, 2)
+
+	assert.Equal(t, "postgres", body.Checks[0].Name)
+	assert.Equal(t, "success", body.Checks[0].Status)
+	assert.Equal(t, "redis", body.Checks[1].Name)
+	assert.Equal(t, "failure", body.Checks[1].Status)
+}
+
+func TestFiberServer_Probes_Accept(t *testing.T) {
+	t.Parallel()
+
+	// Arrange.
+	app := fiber.New()
+
+	Fiber(app).Probes(NewCompositeProbes(), WithRenderers(HealthRenderer{ServiceID: "billing"}))
+
+	tests := []struct {
+		name                string
+		accept              string
+		expectedContentType string
+		expectedBody        string
+	}{
+		{
+			name:                "Формат по умолчанию",
+			accept:              "*/*",
+			expectedContentType: "",
+			expectedBody:        "",
+		},
+		{
+			name:                "Формат application/health+json",
+			accept:              HealthContentType,
+			expectedContentType: HealthContentType,
+			expectedBody:        `{"status":"pass","serviceId":"billing"}`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			request := httptest.NewRequest(fiber.MethodGet, DefaultLivenessPath, nil)
+			request.Header.Set(fiber.HeaderAccept, test.accept)
+
+			// Act.
+			response, err := app.Test(request)
+
+			// Assert.
+			require.NoError(t, err)
+
+			body, err := io.ReadAll(response.Body)
+			require.NoError(t, err)
+
+			assert.Equal(t, fiber.StatusOK, response.StatusCode)
+			assert.Equal(t, test.expectedContentType, response.Header.Get(fiber.HeaderContentType))
+			assert.Equal(t, test.expectedBody, string(body))
+		})
+	}
+}
+
+func TestFiberServer_Probes_Verbose(t *testing.T) {
+	t.Parallel()
+
+	// Arrange.
+	app := fiber.New()
+
+	probes := NewCompositeProbes().
+		RegisterReadiness("postgres", testProbe(Success, nil)).
+		RegisterReadiness("redis", testProbe(Failure, dummyFiberError)).
+		RegisterReadiness("kafka", testProbe(Failure, nil))
+
+	Fiber(app).Probes(probes)
+
+	tests := []struct {
+		name           string
+		target         string
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:           "Подробный ответ",
+			target:         DefaultReadinessPath + "?verbose",
+			expectedStatus: fiber.StatusInternalServerError,
+			expectedBody:   "[+]postgres ok\n[-]redis failed: fiber: dummy error\n[-]kafka failed: failure\nreadiness check failed\n",
+		},
+		{
+			name:           "Исключение проверок",
+			target:         DefaultReadinessPath + "?verbose&exclude=redis&exclude=kafka",
+			expectedStatus: fiber.StatusOK,
+			expectedBody:   "[+]postgres ok\nreadiness check passed\n",
+		},
+		{
+			name:           "Исключение проверок через запятую",
+			target:         DefaultReadinessPath + "?exclude=redis,kafka",
+			expectedStatus: fiber.StatusOK,
+			expectedBody:   "",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Act.
+			response, err := app.Test(httptest.NewRequest(fiber.MethodGet, test.target, nil))
+
+			// Assert.
+			require.NoError(t, err)
+
+			body, err := io.ReadAll(response.Body)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expectedStatus, response.StatusCode)
+			assert.Equal(t, test.expectedBody, string(body))
+		})
+	}
+}
+
+func TestFiberServer_Probes_Check(t *testing.T) {
+	t.Parallel()
+
+	// Arrange.
+	app := fiber.New()
+
+	probes := NewCompositeProbes().
+		RegisterLiveness("deadlock-watchdog", testProbe(Success, nil)).
+		RegisterReadiness("postgres", testProbe(Warning, dummyFiberError)).
+		RegisterReadiness("redis", testProbe(Failure, dummyFiberError))
+
+	Fiber(app).Probes(probes)
+
+	tests := []struct {
+		name           string
+		target         string
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:           "Успешная проверка",
+			target:         DefaultLivenessPath + "/deadlock-watchdog",
+			expectedStatus: fiber.StatusOK,
+			expectedBody:   "",
+		},
+		{
+			name:           `Проверка с результатом "Warning"`,
+			target:         DefaultReadinessPath + "/postgres",
+			expectedStatus: fiber.StatusOK,
+			expectedBody:   "postgres: fiber: dummy error",
+		},
+		{
+			name:           `Проверка с результатом "Failure"`,
+			target:         DefaultReadinessPath + "/redis",
+			expectedStatus: fiber.StatusInternalServerError,
+			expectedBody:   "redis: fiber: dummy error",
+		},
+		{
+			name:           "Неизвестная проверка",
+			target:         DefaultReadinessPath + "/kafka",
+			expectedStatus: fiber.StatusNotFound,
+			expectedBody:   `probes: unknown check: "kafka"`,
+		},
+		{
+			name:           "Проверка другой пробы",
+			target:         DefaultStartupPath + "/postgres",
+			expectedStatus: fiber.StatusNotFound,
+			expectedBody:   `probes: unknown check: "postgres"`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Act.
+			response, err := app.Test(httptest.NewRequest(fiber.MethodGet, test.target, nil))
+
+			// Assert.
+			require.NoError(t, err)
+
+			body, err := io.ReadAll(response.Body)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expectedStatus, response.StatusCode)
+			assert.Equal(t, test.expectedBody, string(body))
+		})
+	}
+}
+
+func TestFiberReadiness_Check(t *testing.T) {
+	t.Parallel()
+
+	// Arrange.
+	app := fiber.New()
+
+	app.Get(checkRoute(DefaultReadinessPath), NewFiberReadiness(DefaultReadiness).Check)
+
+	// Act.
+	response, err := app.Test(httptest.NewRequest(fiber.MethodGet, DefaultReadinessPath+"/postgres", nil))
+
+	// Assert.
+	require.NoError(t, err)
+
+	assert.Equal(t, fiber.StatusNotFound, response.StatusCode)
+}
+
+func TestFiberServer_Probes_CheckTimeout(t *testing.T) {
+	t.Parallel()
+
+	// Arrange.
+	app := fiber.New()
+
+	probes := NewCompositeProbes().
+		RegisterReadiness("postgres", ProbeFunc(testBlockingProbe))
+
+	Fiber(app).Probes(probes)
+
+	// Act.
+	response, err := app.Test(httptest.NewRequest(fiber.MethodGet, DefaultReadinessPath, nil),
+		int(3*DefaultCheckTimeout/time.Millisecond))
+
+	// Assert.
+	require.NoError(t, err)
+
+	body, err := io.ReadAll(response.Body)
+	require.NoError(t, err)
+
+	assert.Equal(t, fiber.StatusInternalServerError, response.StatusCode)
+	assert.Equal(t, "postgres: probes: check timed out", string(body))
+}
+
+func TestFiberServer_Probes_Options(t *testing.T) {
+	t.Parallel()
+
+	// Arrange.
+	app := fiber.New()
+
+	Fiber(app,
+		WithPathPrefix("/health/"),
+		WithLivenessPath("/livez"),
+		WithReadinessPath("/readyz"),
+		WithoutStartup(),
+		WithMethods(fiber.MethodGet),
+	).Probes(DefaultProbes)
+
+	tests := []struct {
+		name           string
+		method         string
+		target         string
+		expectedStatus int
+	}{
+		{
+			name:           "Liveness-эндпоинт",
+			method:         fiber.MethodGet,
+			target:         "/health/livez",
+			expectedStatus: fiber.StatusOK,
+		},
+		{
+			name:           "Readiness-эндпоинт",
+			method:         fiber.MethodGet,
+			target:         "/health/readyz",
+			expectedStatus: fiber.StatusOK,
+		},
+		{
+			name:           "Отключённый Startup-эндпоинт",
+			method:         fiber.MethodGet,
+			target:         "/health/startup",
+			expectedStatus: fiber.StatusNotFound,
+		},
+		{
+			name:           "Путь по умолчанию",
+			method:         fiber.MethodGet,
+			target:         DefaultLivenessPath,
+			expectedStatus: fiber.StatusNotFound,
+		},
+		{
+			name:           "Неподдерживаемый метод",
+			method:         fiber.MethodHead,
+			target:         "/health/livez",
+			expectedStatus: fiber.StatusMethodNotAllowed,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Act.
+			response, err := app.Test(httptest.NewRequest(test.method, test.target, nil))
+
+			// Assert.
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expectedStatus, response.StatusCode)
+		})
+	}
+}
+
+func TestFiberServer_Probes_Head(t *testing.T) {
+	t.Parallel()
+
+	// Arrange.
+	app := fiber.New()
+
+	Fiber(app).Probes(DefaultProbes)
+
+	// Act.
+	response, err := app.Test(httptest.NewRequest(fiber.MethodHead, DefaultReadinessPath, nil))
+
+	// Assert.
+	require.NoError(t, err)
+
+	assert.Equal(t, fiber.StatusOK, response.StatusCode)
+}
+
+func TestFiberServer_Override(t *testing.T) {
+	t.Parallel()
+
+	// Arrange.
+	app := fiber.New()
+
+	override := NewOverride(DefaultProbes)
+
+	Fiber(app, WithPathPrefix("/health")).Probes(override).Override(override, "secret")
+
+	request := httptest.NewRequest(fiber.MethodPut, "/health"+DefaultOverridePath,
+		strings.NewReader(`{"result":"failure","reason":"profiling","ttl":"1h"}`))
+	request.Header.Set(fiber.HeaderAuthorization, "Bearer secret")
+
+	// Act.
+	response, err := app.Test(request)
+
+	// Assert.
+	require.NoError(t, err)
+	require.Equal(t, fiber.StatusOK, response.StatusCode)
+
+	response, err = app.Test(httptest.NewRequest(fiber.MethodGet, "/health"+DefaultReadinessPath, nil))
+	require.NoError(t, err)
+
+	body, err := io.ReadAll(response.Body)
+	require.NoError(t, err)
+
+	assert.Equal(t, fiber.StatusInternalServerError, response.StatusCode)
+	assert.Equal(t, "probes: readiness overridden: profiling", string(body))
+
+	response, err = app.Test(httptest.NewRequest(fiber.MethodDelete, "/health"+DefaultOverridePath, nil))
+	require.NoError(t, err)
+
+	assert.Equal(t, fiber.StatusUnauthorized, response.StatusCode)
+}
+
+func TestFiberServer_Override_BodyLimit(t *testing.T) {
+	t.Parallel()
+
+	// Arrange.
+	app := fiber.New()
+
+	Fiber(app).Override(NewOverride(DefaultProbes), "secret")
+
+	tests := []struct {
+		name           string
+		authorization  string
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:           "Без токена",
+			expectedStatus: fiber.StatusUnauthorized,
+			expectedBody:   "Unauthorized",
+		},
+		{
+			name:           "Тело превышает ограничение",
+			authorization:  "Bearer secret",
+			expectedStatus: fiber.StatusBadRequest,
+			expectedBody:   ErrOverrideTooLarge.Error(),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			request := httptest.NewRequest(fiber.MethodPut, DefaultOverridePath,
+				strings.NewReader(strings.Repeat(" ", overrideBodyLimit+1)))
+			request.Header.Set(fiber.HeaderAuthorization, test.authorization)
+
+			// Act.
+			response, err := app.Test(request)
+
+			// Assert.
+			require.NoError(t, err)
+
+			body, err := io.ReadAll(response.Body)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expectedStatus, response.StatusCode)
+			assert.Equal(t, test.expectedBody, string(body))
+		})
+	}
+}
+
 func TestFiberServer_Start(t *testing.T) {
 	// Arrange.
 	app := fiber.New()
@@ -88,6 +513,80 @@ func TestFiberServer_Start(t *testing.T) {
 	}
 }
 
+func TestFiberServer_StartUnix(t *testing.T) {
+	t.Parallel()
+
+	// Arrange.
+	socket := filepath.Join(t.TempDir(), "probes.sock")
+
+	app := fiber.New(fiber.Config{DisableStartupMessage: true})
+
+	server := Fiber(app).Probes(DefaultProbes)
+
+	// Act.
+	go func() {
+		assert.NoError(t, server.StartUnix(socket, 0o600))
+	}()
+
+	// Assert.
+	client := testUnixClient(socket)
+
+	assert.Eventually(t, func() bool {
+		response, err := client.Get("http://unix" + DefaultLivenessPath)
+		if err != nil {
+			return false
+		}
+
+		_ = response.Body.Close()
+
+		return response.StatusCode == fiber.StatusOK
+	}, time.Second, 10*time.Millisecond)
+
+	info, err := os.Stat(socket)
+	require.NoError(t, err)
+
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+	require.NoError(t, app.Shutdown())
+}
+
+func TestFiberServer_Shutdown(t *testing.T) {
+	t.Parallel()
+
+	// Arrange.
+	socket := filepath.Join(t.TempDir(), "probes.sock")
+
+	app := fiber.New(fiber.Config{DisableStartupMessage: true})
+
+	server := Fiber(app).Probes(DefaultProbes)
+
+	done := make(chan error, 1)
+
+	go func() {
+		done <- server.StartUnix(socket, 0o600)
+	}()
+
+	client := testUnixClient(socket)
+
+	require.Eventually(t, func() bool {
+		response, err := client.Get("http://unix" + DefaultLivenessPath)
+		if err != nil {
+			return false
+		}
+
+		_ = response.Body.Close()
+
+		return true
+	}, time.Second, 10*time.Millisecond)
+
+	// Act.
+	err := server.Shutdown(context.Background())
+
+	// Assert.
+	require.NoError(t, err)
+	assert.NoError(t, <-done)
+}
+
 type testSuccessLiveness struct{}
 
 func (t testSuccessLiveness) Liveness(context.Context) (Result, error) {
@@ -225,6 +724,29 @@ func TestFiberLiveness_Liveness(t *testing.T) {
 	}
 }
 
+func TestNewFiberReadiness_StatusMapper(t *testing.T) {
+	t.Parallel()
+
+	// Arrange.
+	app := fiber.New()
+
+	handler := NewFiberReadiness(testFailureReadinessErrorless{},
+		WithStatuses(map[Result]int{Failure: fiber.StatusServiceUnavailable}),
+		WithResultHeader("X-Probe-Result"),
+	)
+
+	app.Get(DefaultReadinessPath, handler.Readiness)
+
+	// Act.
+	response, err := app.Test(httptest.NewRequest(fiber.MethodGet, DefaultReadinessPath, nil))
+
+	// Assert.
+	require.NoError(t, err)
+
+	assert.Equal(t, fiber.StatusServiceUnavailable, response.StatusCode)
+	assert.Equal(t, "failure", response.Header.Get("X-Probe-Result"))
+}
+
 func TestNewFiberLiveness(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,198 @@
+package probes
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+// DefaultWatchInterval содержит интервал по умолчанию,
+// с которым GRPCHealthServer выполняет пробу для
+// потоковых Watch-запросов.
+const DefaultWatchInterval = 5 * time.Second
+
+// GRPCOption настраивает GRPCHealthServer.
+type GRPCOption func(*GRPCHealthServer)
+
+// WithWatchInterval задаёт интервал, с которым проба
+// выполняется для потоковых Watch-запросов.
+//
+// По умолчанию, а также вместо нулевого или
+// отрицательного интервала, используется
+// DefaultWatchInterval.
+func WithWatchInterval(interval time.Duration) GRPCOption {
+	return func(server *GRPCHealthServer) {
+		if interval <= 0 {
+			interval = DefaultWatchInterval
+		}
+
+		server.interval = interval
+	}
+}
+
+// WithGRPCService регистрирует дополнительный сервис
+// с указанным именем, состояние которого определяется
+// пробой.
+//
+// Дополнительные сервисы имеют приоритет над сервисами
+// по умолчанию.
+func WithGRPCService(name string, probe ProbeFunc) GRPCOption {
+	return func(server *GRPCHealthServer) {
+		server.services[name] = probe
+	}
+}
+
+// GRPCHealthServer реализует протокол gRPC Health
+// Checking (grpc.health.v1.Health), который использует
+// встроенная gRPC-проба Kubernetes.
+//
+// Имена сервисов сопоставляются с пробами:
+//
+//	"" и "liveness" – Liveness
+//	"readiness"     – Readiness
+//	"startup"       – Startup
+//	"readiness/postgres" – именованная проверка CompositeProbes
+//
+// Success и Warning соответствуют статусу SERVING,
+// Failure – NOT_SERVING, Unknown – UNKNOWN. Для
+// неизвестного сервиса Check возвращает ошибку с кодом
+// NotFound, а Watch – статус SERVICE_UNKNOWN.
+//
+// Для инициализации необходимо использовать метод
+// NewGRPCHealthServer.
+type GRPCHealthServer struct {
+	grpc_health_v1.UnimplementedHealthServer
+
+	probes   Probes
+	services map[string]ProbeFunc
+	interval time.Duration
+}
+
+// NewGRPCHealthServer инициализирует gRPC-сервер
+// протокола Health Checking для проб Kubernetes.
+func NewGRPCHealthServer(probes Probes, options ...GRPCOption) *GRPCHealthServer {
+	server := &GRPCHealthServer{
+		probes:   probes,
+		services: make(map[string]ProbeFunc),
+		interval: DefaultWatchInterval,
+	}
+
+	for _, option := range options {
+		option(server)
+	}
+
+	return server
+}
+
+// Register регистрирует сервер на gRPC-сервере.
+func (server *GRPCHealthServer) Register(registrar grpc.ServiceRegistrar) {
+	grpc_health_v1.RegisterHealthServer(registrar, server)
+}
+
+// Check возвращает текущее состояние сервиса.
+func (server *GRPCHealthServer) Check(
+	ctx context.Context,
+	request *grpc_health_v1.HealthCheckRequest,
+) (*grpc_health_v1.HealthCheckResponse, error) {
+	serving := server.status(ctx, request.GetService())
+	if serving == grpc_health_v1.HealthCheckResponse_SERVICE_UNKNOWN {
+		return nil, status.Errorf(codes.NotFound, "unknown service %q", request.GetService())
+	}
+
+	return &grpc_health_v1.HealthCheckResponse{Status: serving}, nil
+}
+
+// Watch отправляет текущее состояние сервиса, а затем
+// каждое его изменение, пока клиент не закроет поток.
+func (server *GRPCHealthServer) Watch(
+	request *grpc_health_v1.HealthCheckRequest,
+	stream grpc_health_v1.Health_WatchServer,
+) error {
+	ctx := stream.Context()
+
+	ticker := time.NewTicker(server.interval)
+	defer ticker.Stop()
+
+	last := grpc_health_v1.HealthCheckResponse_ServingStatus(-1)
+
+	for {
+		serving := server.status(ctx, request.GetService())
+		if serving != last {
+			err := stream.Send(&grpc_health_v1.HealthCheckResponse{Status: serving})
+			if err != nil {
+				return err
+			}
+
+			last = serving
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return status.FromContextError(ctx.Err()).Err()
+		}
+	}
+}
+
+func (server *GRPCHealthServer) status(
+	ctx context.Context,
+	service string,
+) grpc_health_v1.HealthCheckResponse_ServingStatus {
+	result, found := server.check(ctx, service)
+	if !found {
+		return grpc_health_v1.HealthCheckResponse_SERVICE_UNKNOWN
+	}
+
+	switch {
+	case result.IsSuccess() || result.IsWarning():
+		return grpc_health_v1.HealthCheckResponse_SERVING
+	case result.IsUnknown():
+		return grpc_health_v1.HealthCheckResponse_UNKNOWN
+	default:
+		return grpc_health_v1.HealthCheckResponse_NOT_SERVING
+	}
+}
+
+func (server *GRPCHealthServer) check(ctx context.Context, service string) (Result, bool) {
+	if probe, found := server.services[service]; found {
+		result, _ := probe(ctx)
+
+		return result, true
+	}
+
+	switch service {
+	case "", livenessProbe:
+		return livenessReporter(server.probes)(ctx).Result, true
+	case readinessProbe:
+		return readinessReporter(server.probes)(ctx).Result, true
+	case startupProbe:
+		return startupReporter(server.probes)(ctx).Result, true
+	}
+
+	kind, name, found := strings.Cut(service, "/")
+	if !found {
+		return Failure, false
+	}
+
+	var reporter checkReporter
+
+	switch kind {
+	case livenessProbe:
+		reporter = livenessCheckReporter(server.probes)
+	case readinessProbe:
+		reporter = readinessCheckReporter(server.probes)
+	case startupProbe:
+		reporter = startupCheckReporter(server.probes)
+	default:
+		return Failure, false
+	}
+
+	report, found := reporter(ctx, name)
+
+	return report.Result, found
+}
@@ -0,0 +1,209 @@
+package probes
+
+import (
+	"context"
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func testGRPCHealthClient(t *testing.T, server *GRPCHealthServer) grpc_health_v1.HealthClient {
+	t.Helper()
+
+	listener := bufconn.Listen(1024 * 1024)
+
+	grpcServer := grpc.NewServer()
+	server.Register(grpcServer)
+
+	go func() {
+		_ = grpcServer.Serve(listener)
+	}()
+
+	t.Cleanup(grpcServer.Stop)
+
+	conn, err := grpc.Dial("bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		_ = conn.Close()
+	})
+
+	return grpc_health_v1.NewHealthClient(conn)
+}
+
+func TestNewGRPCHealthServer(t *testing.T) {
+	t.Parallel()
+
+	// Act.
+	server := NewGRPCHealthServer(DefaultProbes, WithWatchInterval(time.Second))
+
+	// Assert.
+	assert.Equal(t, DefaultProbes, server.probes)
+	assert.Equal(t, time.Second, server.interval)
+	assert.Empty(t, server.services)
+}
+
+func TestWithWatchInterval(t *testing.T) {
+	t.Parallel()
+
+	// Act.
+	server := NewGRPCHealthServer(DefaultProbes, WithWatchInterval(0))
+
+	// Assert.
+	assert.Equal(t, DefaultWatchInterval, server.interval)
+}
+
+func TestGRPCHealthServer_Check(t *testing.T) {
+	t.Parallel()
+
+	// Arrange.
+	probes := NewCompositeProbes().
+		RegisterLiveness("deadlock-watchdog", testProbe(Success, nil)).
+		RegisterReadiness("postgres", testProbe(Warning, errDummyProbe)).
+		RegisterReadiness("redis", testProbe(Failure, errDummyProbe)).
+		RegisterStartup("migrations", testProbe(Success, nil))
+
+	client := testGRPCHealthClient(t, NewGRPCHealthServer(probes,
+		WithGRPCService("billing", testProbe(Success, nil)),
+		WithGRPCService("cache-warmup", testProbe(Unknown, nil)),
+	))
+
+	tests := []struct {
+		name           string
+		service        string
+		expectedStatus grpc_health_v1.HealthCheckResponse_ServingStatus
+		expectedCode   codes.Code
+	}{
+		{
+			name:           "Сервис по умолчанию",
+			service:        "",
+			expectedStatus: grpc_health_v1.HealthCheckResponse_SERVING,
+			expectedCode:   codes.OK,
+		},
+		{
+			name:           "Readiness-проба",
+			service:        "readiness",
+			expectedStatus: grpc_health_v1.HealthCheckResponse_NOT_SERVING,
+			expectedCode:   codes.OK,
+		},
+		{
+			name:           "Startup-проба",
+			service:        "startup",
+			expectedStatus: grpc_health_v1.HealthCheckResponse_SERVING,
+			expectedCode:   codes.OK,
+		},
+		{
+			name:           `Именованная проверка с результатом "Warning"`,
+			service:        "readiness/postgres",
+			expectedStatus: grpc_health_v1.HealthCheckResponse_SERVING,
+			expectedCode:   codes.OK,
+		},
+		{
+			name:           `Именованная проверка с результатом "Failure"`,
+			service:        "readiness/redis",
+			expectedStatus: grpc_health_v1.HealthCheckResponse_NOT_SERVING,
+			expectedCode:   codes.OK,
+		},
+		{
+			name:           "Дополнительный сервис",
+			service:        "billing",
+			expectedStatus: grpc_health_v1.HealthCheckResponse_SERVING,
+			expectedCode:   codes.OK,
+		},
+		{
+			name:           `Дополнительный сервис с результатом "Unknown"`,
+			service:        "cache-warmup",
+			expectedStatus: grpc_health_v1.HealthCheckResponse_UNKNOWN,
+			expectedCode:   codes.OK,
+		},
+		{
+			name:         "Неизвестная проверка",
+			service:      "liveness/kafka",
+			expectedCode: codes.NotFound,
+		},
+		{
+			name:         "Неизвестный сервис",
+			service:      "kafka",
+			expectedCode: codes.NotFound,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Act.
+			response, err := client.Check(context.Background(), &grpc_health_v1.HealthCheckRequest{Service: test.service})
+
+			// Assert.
+			assert.Equal(t, test.expectedCode, status.Code(err))
+			assert.Equal(t, test.expectedStatus, response.GetStatus())
+		})
+	}
+}
+
+func TestGRPCHealthServer_Watch(t *testing.T) {
+	t.Parallel()
+
+	// Arrange.
+	var result int32 = int32(Success)
+
+	probes := NewCompositeProbes().
+		RegisterReadiness("postgres", ProbeFunc(func(context.Context) (Result, error) {
+			return Result(atomic.LoadInt32(&result)), nil
+		}))
+
+	client := testGRPCHealthClient(t, NewGRPCHealthServer(probes, WithWatchInterval(10*time.Millisecond)))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Act.
+	stream, err := client.Watch(ctx, &grpc_health_v1.HealthCheckRequest{Service: "readiness"})
+	require.NoError(t, err)
+
+	first, err := stream.Recv()
+	require.NoError(t, err)
+
+	atomic.StoreInt32(&result, int32(Failure))
+
+	second, err := stream.Recv()
+	require.NoError(t, err)
+
+	// Assert.
+	assert.Equal(t, grpc_health_v1.HealthCheckResponse_SERVING, first.GetStatus())
+	assert.Equal(t, grpc_health_v1.HealthCheckResponse_NOT_SERVING, second.GetStatus())
+}
+
+func TestGRPCHealthServer_Watch_UnknownService(t *testing.T) {
+	t.Parallel()
+
+	// Arrange.
+	client := testGRPCHealthClient(t, NewGRPCHealthServer(DefaultProbes))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Act.
+	stream, err := client.Watch(ctx, &grpc_health_v1.HealthCheckRequest{Service: "kafka"})
+	require.NoError(t, err)
+
+	response, err := stream.Recv()
+
+	// Assert.
+	require.NoError(t, err)
+
+	assert.Equal(t, grpc_health_v1.HealthCheckResponse_SERVICE_UNKNOWN, response.GetStatus())
+}
@@ -1,5 +1,16 @@
 package probes
 
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
 const (
 	// DefaultServerAddress содержит путь по умолчанию
 	// REST-сервера для обработчиков проб Kubernetes.
@@ -17,3 +28,418 @@ const (
 	// для HTTP-обработчика Startup-запросов Kubernetes.
 	DefaultStartupPath = "/startup"
 )
+
+// ServerOption настраивает REST-сервер с пробами
+// Kubernetes: адрес, пути и HTTP-методы эндпоинтов.
+type ServerOption func(*serverConfig)
+
+// WithAddress задаёт адрес REST-сервера.
+//
+// По умолчанию используется DefaultServerAddress.
+func WithAddress(address string) ServerOption {
+	return func(config *serverConfig) {
+		config.address = address
+	}
+}
+
+// WithPathPrefix задаёт общий префикс путей всех
+// эндпоинтов, например /health.
+func WithPathPrefix(prefix string) ServerOption {
+	return func(config *serverConfig) {
+		config.prefix = strings.TrimSuffix(prefix, "/")
+	}
+}
+
+// WithLivenessPath задаёт путь Liveness-эндпоинта,
+// например /livez.
+//
+// По умолчанию используется DefaultLivenessPath.
+func WithLivenessPath(path string) ServerOption {
+	return func(config *serverConfig) {
+		config.livenessPath = path
+	}
+}
+
+// WithReadinessPath задаёт путь Readiness-эндпоинта,
+// например /readyz.
+//
+// По умолчанию используется DefaultReadinessPath.
+func WithReadinessPath(path string) ServerOption {
+	return func(config *serverConfig) {
+		config.readinessPath = path
+	}
+}
+
+// WithStartupPath задаёт путь Startup-эндпоинта,
+// например /healthz.
+//
+// По умолчанию используется DefaultStartupPath.
+func WithStartupPath(path string) ServerOption {
+	return func(config *serverConfig) {
+		config.startupPath = path
+	}
+}
+
+// WithOverridePath задаёт путь административного
+// эндпоинта Override.
+//
+// По умолчанию используется DefaultOverridePath.
+func WithOverridePath(path string) ServerOption {
+	return func(config *serverConfig) {
+		config.overridePath = path
+	}
+}
+
+// WithMetricsPath задаёт путь эндпоинта метрик
+// Prometheus.
+//
+// По умолчанию используется DefaultMetricsPath.
+func WithMetricsPath(path string) ServerOption {
+	return func(config *serverConfig) {
+		config.metricsPath = path
+	}
+}
+
+// WithoutLiveness отключает Liveness-эндпоинт.
+func WithoutLiveness() ServerOption {
+	return func(config *serverConfig) {
+		config.livenessDisabled = true
+	}
+}
+
+// WithoutReadiness отключает Readiness-эндпоинт.
+func WithoutReadiness() ServerOption {
+	return func(config *serverConfig) {
+		config.readinessDisabled = true
+	}
+}
+
+// WithoutStartup отключает Startup-эндпоинт.
+func WithoutStartup() ServerOption {
+	return func(config *serverConfig) {
+		config.startupDisabled = true
+	}
+}
+
+// WithMethods задаёт HTTP-методы, на которые отвечают
+// эндпоинты.
+//
+// По умолчанию используются GET и HEAD.
+func WithMethods(methods ...string) ServerOption {
+	return func(config *serverConfig) {
+		config.methods = methods
+	}
+}
+
+type serverConfig struct {
+	address string
+	prefix  string
+	methods []string
+
+	livenessPath  string
+	readinessPath string
+	startupPath   string
+	overridePath  string
+	metricsPath   string
+
+	livenessDisabled  bool
+	readinessDisabled bool
+	startupDisabled   bool
+}
+
+// ErrSocketPathInUse указывает, что по пути unix-сокета
+// расположен файл, не являющийся сокетом.
+var ErrSocketPathInUse = errors.New("probes: unix socket path is in use by another file")
+
+// listenUnix создаёт unix-сокет с правами доступа mode,
+// предварительно удаляя оставшийся от предыдущего
+// запуска файл сокета. Другой файл по пути path не
+// заменяется: возвращается ErrSocketPathInUse.
+//
+// Сокет создаётся во временном каталоге с правами 0700
+// и переносится по пути path только после установки
+// прав, чтобы другие пользователи не могли подключиться
+// к нему раньше.
+func listenUnix(path string, mode os.FileMode) (net.Listener, error) {
+	info, err := os.Lstat(path)
+
+	switch {
+	case err == nil && info.Mode()&os.ModeSocket == 0:
+		return nil, fmt.Errorf("%w: %s", ErrSocketPathInUse, path)
+	case err == nil:
+		if err := os.Remove(path); err != nil {
+			return nil, err
+		}
+	case !errors.Is(err, os.ErrNotExist):
+		return nil, err
+	}
+
+	dir, err := os.MkdirTemp(filepath.Dir(path), ".probes-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	temporary := filepath.Join(dir, "sock")
+
+	listener, err := net.Listen("unix", temporary)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := os.Chmod(temporary, mode); err != nil {
+		_ = listener.Close()
+
+		return nil, err
+	}
+
+	if err := os.Rename(temporary, path); err != nil {
+		_ = listener.Close()
+
+		return nil, err
+	}
+
+	return unixListener{Listener: listener, path: path}, nil
+}
+
+// unixListener удаляет файл сокета при закрытии, как
+// net.UnixListener, но по итоговому пути сокета.
+type unixListener struct {
+	net.Listener
+	path string
+}
+
+func (listener unixListener) Close() error {
+	err := listener.Listener.Close()
+
+	if removeErr := os.Remove(listener.path); removeErr != nil && !errors.Is(removeErr, os.ErrNotExist) && err == nil {
+		err = removeErr
+	}
+
+	return err
+}
+
+func newServerConfig(options []ServerOption) serverConfig {
+	config := serverConfig{
+		address:       DefaultServerAddress,
+		methods:       []string{http.MethodGet, http.MethodHead},
+		livenessPath:  DefaultLivenessPath,
+		readinessPath: DefaultReadinessPath,
+		startupPath:   DefaultStartupPath,
+		overridePath:  DefaultOverridePath,
+		metricsPath:   DefaultMetricsPath,
+	}
+
+	for _, option := range options {
+		option(&config)
+	}
+
+	return config
+}
+
+func (config serverConfig) path(path string) string {
+	return config.prefix + path
+}
+
+// HandlerOption настраивает HTTP-обработчики проб
+// Kubernetes.
+type HandlerOption func(*handlerConfig)
+
+// WithRenderer задаёт Renderer, который формирует тело
+// ответа обработчика.
+//
+// По умолчанию используется DefaultRenderer.
+func WithRenderer(renderer Renderer) HandlerOption {
+	return func(config *handlerConfig) {
+		config.renderer = renderer
+	}
+}
+
+// WithJSON включает тело ответа в формате JSON.
+//
+//	Смотри JSONRenderer
+func WithJSON() HandlerOption {
+	return WithRenderer(JSONRenderer{})
+}
+
+// WithRenderers добавляет Renderer, которые выбираются
+// по заголовку Accept запроса. Если ни один из них не
+// подходит под заголовок, используется Renderer по
+// умолчанию.
+//
+// Например, для поддержки формата application/health+json:
+//
+//	probes.WithRenderers(probes.HealthRenderer{ServiceID: "billing"})
+func WithRenderers(renderers ...Renderer) HandlerOption {
+	return func(config *handlerConfig) {
+		config.negotiable = append(config.negotiable, renderers...)
+	}
+}
+
+// StatusMapper сопоставляет результату пробы код
+// HTTP-ответа.
+type StatusMapper func(Result) int
+
+// DefaultStatusMapper возвращает HTTP 200 OK для Success
+// и Warning, HTTP 503 Service Unavailable для Unknown и
+// HTTP 500 Internal Server Error для Failure и
+// неподдерживаемых результатов.
+func DefaultStatusMapper(result Result) int {
+	switch {
+	case result.IsSuccess() || result.IsWarning():
+		return http.StatusOK
+	case result.IsUnknown():
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// WithStatusMapper задаёт StatusMapper обработчика.
+//
+// По умолчанию используется DefaultStatusMapper.
+func WithStatusMapper(mapper StatusMapper) HandlerOption {
+	return func(config *handlerConfig) {
+		config.statusMapper = mapper
+	}
+}
+
+// WithStatuses переопределяет коды HTTP-ответа для
+// отдельных результатов, например HTTP 503 Service
+// Unavailable для Failure. Для остальных результатов
+// используется DefaultStatusMapper.
+func WithStatuses(statuses map[Result]int) HandlerOption {
+	return WithStatusMapper(func(result Result) int {
+		status, found := statuses[result]
+		if found {
+			return status
+		}
+
+		return DefaultStatusMapper(result)
+	})
+}
+
+// WithResultHeader добавляет в ответ заголовок с
+// строковым значением результата пробы, например
+// X-Probe-Result: warning.
+func WithResultHeader(header string) HandlerOption {
+	return func(config *handlerConfig) {
+		config.resultHeader = header
+	}
+}
+
+// WithMetrics задаёт сборщик метрик, который учитывает
+// каждый запрос пробы.
+//
+// Запросы именованных проверок и запросы с параметром
+// ?exclude не учитываются, чтобы не искажать результат
+// пробы.
+func WithMetrics(metrics *Metrics) HandlerOption {
+	return func(config *handlerConfig) {
+		config.metrics = metrics
+	}
+}
+
+type handlerConfig struct {
+	renderer     Renderer
+	negotiable   []Renderer
+	statusMapper StatusMapper
+	resultHeader string
+	metrics      *Metrics
+}
+
+func newHandlerConfig(options []HandlerOption) handlerConfig {
+	var config handlerConfig
+
+	for _, option := range options {
+		option(&config)
+	}
+
+	return config
+}
+
+// ErrUnknownCheck указывает, что запрошенная именованная
+// проверка не зарегистрирована.
+var ErrUnknownCheck = errors.New("probes: unknown check")
+
+const (
+	verboseParam = "verbose"
+	excludeParam = "exclude"
+	checkParam   = "check"
+)
+
+type request struct {
+	accept  string
+	verbose bool
+	exclude []string
+}
+
+func (request request) context(parent context.Context) context.Context {
+	if len(request.exclude) == 0 {
+		return parent
+	}
+
+	return WithExcludedChecks(parent, request.exclude...)
+}
+
+type response struct {
+	status      int
+	contentType string
+	headers     map[string]string
+	body        []byte
+}
+
+func (config handlerConfig) respond(request request, report Report) (response, error) {
+	renderer := config.renderer
+	if renderer == nil {
+		renderer = DefaultRenderer
+	}
+
+	renderer = negotiate(request.accept, renderer, config.negotiable)
+	if request.verbose {
+		renderer = VerboseRenderer{}
+	}
+
+	body, err := renderer.Render(report)
+	if err != nil {
+		return response{}, err
+	}
+
+	statusMapper := config.statusMapper
+	if statusMapper == nil {
+		statusMapper = DefaultStatusMapper
+	}
+
+	response := response{
+		status: statusMapper(report.Result),
+		body:   body,
+	}
+
+	if config.resultHeader != "" {
+		response.headers = map[string]string{config.resultHeader: report.Result.String()}
+	}
+
+	if len(body) > 0 {
+		response.contentType = renderer.ContentType()
+	}
+
+	return response, nil
+}
+
+func (config handlerConfig) observe(request request, report Report) {
+	if config.metrics != nil && len(request.exclude) == 0 {
+		config.metrics.Observe(report)
+	}
+}
+
+func checkRoute(path string) string {
+	return strings.TrimSuffix(path, "/") + "/:" + checkParam
+}
+
+func unknownCheck(name string) response {
+	return response{
+		status:      http.StatusNotFound,
+		contentType: TextContentType,
+		body:        []byte(fmt.Sprintf("%s: %q", ErrUnknownCheck, name)),
+	}
+}
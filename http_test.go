@@ -0,0 +1,255 @@
+package probes
+
+import (
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandlerConfig_Respond(t *testing.T) {
+	t.Parallel()
+
+	// Arrange.
+	tests := []struct {
+		name             string
+		options          []HandlerOption
+		report           Report
+		expectedResponse response
+	}{
+		{
+			name:    `Результат "Success" без ошибки`,
+			options: nil,
+			report:  Report{Result: Success},
+			expectedResponse: response{
+				status: http.StatusOK,
+			},
+		},
+		{
+			name:    `Результат "Warning" с ошибкой`,
+			options: nil,
+			report:  Report{Result: Warning, Err: errDummyProbe},
+			expectedResponse: response{
+				status:      http.StatusOK,
+				contentType: TextContentType,
+				body:        []byte("probes: dummy error"),
+			},
+		},
+		{
+			name:    `Результат "Failure" в формате JSON`,
+			options: []HandlerOption{WithJSON()},
+			report:  Report{Result: Failure},
+			expectedResponse: response{
+				status:      http.StatusInternalServerError,
+				contentType: JSONContentType,
+				body:        []byte(`{"status":"failure","duration_ms":0,"timestamp":"0001-01-01T00:00:00Z"}`),
+			},
+		},
+		{
+			name:    `Переопределённый код ответа для "Failure"`,
+			options: []HandlerOption{WithStatuses(map[Result]int{Failure: http.StatusServiceUnavailable})},
+			report:  Report{Result: Failure},
+			expectedResponse: response{
+				status: http.StatusServiceUnavailable,
+			},
+		},
+		{
+			name: "Пользовательский StatusMapper и заголовок результата",
+			options: []HandlerOption{
+				WithStatusMapper(func(Result) int { return http.StatusMultiStatus }),
+				WithResultHeader("X-Probe-Result"),
+			},
+			report: Report{Result: Warning},
+			expectedResponse: response{
+				status:  http.StatusMultiStatus,
+				headers: map[string]string{"X-Probe-Result": "warning"},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			config := newHandlerConfig(test.options)
+
+			// Act.
+			actualResponse, err := config.respond(request{}, test.report)
+
+			// Assert.
+			assert.NoError(t, err)
+			assert.Equal(t, test.expectedResponse, actualResponse)
+		})
+	}
+}
+
+func TestNewServerConfig(t *testing.T) {
+	t.Parallel()
+
+	// Arrange.
+	tests := []struct {
+		name           string
+		options        []ServerOption
+		expectedConfig serverConfig
+	}{
+		{
+			name:    "Параметры по умолчанию",
+			options: nil,
+			expectedConfig: serverConfig{
+				address:       DefaultServerAddress,
+				methods:       []string{http.MethodGet, http.MethodHead},
+				livenessPath:  DefaultLivenessPath,
+				readinessPath: DefaultReadinessPath,
+				startupPath:   DefaultStartupPath,
+				overridePath:  DefaultOverridePath,
+				metricsPath:   DefaultMetricsPath,
+			},
+		},
+		{
+			name: "Пользовательские параметры",
+			options: []ServerOption{
+				WithAddress(":8080"),
+				WithPathPrefix("/health/"),
+				WithLivenessPath("/livez"),
+				WithReadinessPath("/readyz"),
+				WithStartupPath("/healthz"),
+				WithOverridePath("/admin/override"),
+				WithMetricsPath("/admin/metrics"),
+				WithoutLiveness(),
+				WithoutReadiness(),
+				WithoutStartup(),
+				WithMethods(http.MethodGet),
+			},
+			expectedConfig: serverConfig{
+				address:           ":8080",
+				prefix:            "/health",
+				methods:           []string{http.MethodGet},
+				livenessPath:      "/livez",
+				readinessPath:     "/readyz",
+				startupPath:       "/healthz",
+				overridePath:      "/admin/override",
+				metricsPath:       "/admin/metrics",
+				livenessDisabled:  true,
+				readinessDisabled: true,
+				startupDisabled:   true,
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Act.
+			actualConfig := newServerConfig(test.options)
+
+			// Assert.
+			assert.Equal(t, test.expectedConfig, actualConfig)
+		})
+	}
+}
+
+func TestDefaultStatusMapper(t *testing.T) {
+	t.Parallel()
+
+	// Arrange.
+	tests := []struct {
+		name           string
+		result         Result
+		expectedStatus int
+	}{
+		{
+			name:           `Результат "Success"`,
+			result:         Success,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           `Результат "Warning"`,
+			result:         Warning,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           `Результат "Failure"`,
+			result:         Failure,
+			expectedStatus: http.StatusInternalServerError,
+		},
+		{
+			name:           `Результат "Unknown"`,
+			result:         Unknown,
+			expectedStatus: http.StatusServiceUnavailable,
+		},
+		{
+			name:           "Неподдерживаемый результат",
+			result:         Result(42),
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Act.
+			actualStatus := DefaultStatusMapper(test.result)
+
+			// Assert.
+			assert.Equal(t, test.expectedStatus, actualStatus)
+		})
+	}
+}
+
+func TestListenUnix(t *testing.T) {
+	t.Parallel()
+
+	// Arrange.
+	path := filepath.Join(t.TempDir(), "probes.sock")
+
+	stale, err := net.Listen("unix", path)
+	require.NoError(t, err)
+
+	stale.(*net.UnixListener).SetUnlinkOnClose(false)
+	require.NoError(t, stale.Close())
+
+	// Act.
+	listener, err := listenUnix(path, 0o600)
+
+	// Assert.
+	require.NoError(t, err)
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+	assert.NotZero(t, info.Mode()&os.ModeSocket)
+
+	conn, err := net.Dial("unix", path)
+	require.NoError(t, err)
+	require.NoError(t, conn.Close())
+
+	entries, err := os.ReadDir(filepath.Dir(path))
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+
+	require.NoError(t, listener.Close())
+
+	_, err = os.Stat(path)
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestListenUnix_File(t *testing.T) {
+	t.Parallel()
+
+	// Arrange.
+	path := filepath.Join(t.TempDir(), "important.conf")
+	require.NoError(t, os.WriteFile(path, []byte("keep"), 0o600))
+
+	// Act.
+	listener, err := listenUnix(path, 0o600)
+
+	// Assert.
+	assert.Nil(t, listener)
+	assert.ErrorIs(t, err, ErrSocketPathInUse)
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "keep", string(content))
+
+	entries, err := os.ReadDir(filepath.Dir(path))
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+}
@@ -0,0 +1,302 @@
+package probes
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+)
+
+const (
+	// DefaultPropagationDelay содержит время по умолчанию,
+	// которое Lifecycle ожидает после перевода Readiness
+	// в Failure, чтобы Kubernetes успел исключить под из
+	// балансировки.
+	DefaultPropagationDelay = 5 * time.Second
+
+	// DefaultShutdownTimeout содержит время по умолчанию,
+	// отведённое Lifecycle на выполнение обработчиков
+	// завершения и остановку сервера.
+	DefaultShutdownTimeout = 30 * time.Second
+)
+
+// ErrDraining указывает, что приложение завершает
+// работу и больше не принимает трафик.
+//
+//	Смотри Lifecycle
+var ErrDraining = errors.New("probes: draining")
+
+// Shutdowner оборачивает метод Shutdown, который
+// корректно останавливает сервер.
+//
+// Реализуется FiberServer и HTTPServer.
+type Shutdowner interface {
+	Shutdown(context.Context) error
+}
+
+// LifecycleOption настраивает Lifecycle.
+type LifecycleOption func(*Lifecycle)
+
+// WithPropagationDelay задаёт время ожидания после
+// перевода Readiness в Failure.
+//
+// По умолчанию используется DefaultPropagationDelay.
+func WithPropagationDelay(delay time.Duration) LifecycleOption {
+	return func(lifecycle *Lifecycle) {
+		lifecycle.delay = delay
+	}
+}
+
+// WithShutdownTimeout задаёт время, отведённое на
+// выполнение обработчиков завершения и остановку
+// сервера.
+//
+// По умолчанию используется DefaultShutdownTimeout.
+func WithShutdownTimeout(timeout time.Duration) LifecycleOption {
+	return func(lifecycle *Lifecycle) {
+		lifecycle.timeout = timeout
+	}
+}
+
+// WithServer задаёт сервер, который останавливается
+// последним шагом завершения работы.
+func WithServer(server Shutdowner) LifecycleOption {
+	return func(lifecycle *Lifecycle) {
+		lifecycle.server = server
+	}
+}
+
+// WithSignals задаёт сигналы, по которым Lifecycle.Run
+// начинает завершение работы.
+//
+// По умолчанию используются SIGTERM и SIGINT.
+func WithSignals(signals ...os.Signal) LifecycleOption {
+	return func(lifecycle *Lifecycle) {
+		lifecycle.signals = signals
+	}
+}
+
+// Lifecycle управляет корректным завершением работы
+// приложения при последовательном обновлении подов.
+//
+// При получении SIGTERM или вызове Lifecycle.Drain
+// Lifecycle:
+//
+//  1. сразу переводит Readiness в Failure с ошибкой
+//     ErrDraining;
+//  2. ожидает WithPropagationDelay, пока Kubernetes
+//     исключает под из балансировки;
+//  3. выполняет обработчики завершения, добавленные
+//     Lifecycle.OnShutdown;
+//  4. останавливает сервер, заданный WithServer.
+//
+// Liveness и Startup всё это время возвращают результат
+// исходных проб, поэтому Kubernetes не перезапускает
+// контейнер.
+//
+// Расширенные отчёты и именованные проверки исходных
+// проб, например CompositeProbes, передаются
+// HTTP-обработчикам без изменений, кроме результата
+// Readiness во время завершения работы.
+//
+// Для инициализации необходимо использовать метод
+// NewLifecycle.
+type Lifecycle struct {
+	probes Probes
+
+	delay   time.Duration
+	timeout time.Duration
+	server  Shutdowner
+	signals []os.Signal
+
+	mu       sync.RWMutex
+	draining bool
+	hooks    []func(context.Context) error
+
+	once sync.Once
+	done chan struct{}
+	err  error
+}
+
+// NewLifecycle инициализирует Lifecycle поверх проб
+// Kubernetes.
+func NewLifecycle(probes Probes, options ...LifecycleOption) *Lifecycle {
+	lifecycle := &Lifecycle{
+		probes:  probes,
+		delay:   DefaultPropagationDelay,
+		timeout: DefaultShutdownTimeout,
+		signals: []os.Signal{syscall.SIGTERM, os.Interrupt},
+		done:    make(chan struct{}),
+	}
+
+	for _, option := range options {
+		option(lifecycle)
+	}
+
+	return lifecycle
+}
+
+func (lifecycle *Lifecycle) Liveness(ctx context.Context) (Result, error) {
+	return lifecycle.probes.Liveness(ctx)
+}
+
+func (lifecycle *Lifecycle) Readiness(ctx context.Context) (Result, error) {
+	if lifecycle.Draining() {
+		return Failure, ErrDraining
+	}
+
+	return lifecycle.probes.Readiness(ctx)
+}
+
+func (lifecycle *Lifecycle) Startup(ctx context.Context) (Result, error) {
+	return lifecycle.probes.Startup(ctx)
+}
+
+// LivenessReport возвращает отчёт Liveness-пробы
+// исходных проб, включая результаты их проверок.
+func (lifecycle *Lifecycle) LivenessReport(ctx context.Context) Report {
+	return livenessReporter(lifecycle.probes)(ctx)
+}
+
+// ReadinessReport возвращает отчёт Readiness-пробы
+// исходных проб, а во время завершения работы – Failure
+// с ошибкой ErrDraining без выполнения проверок.
+func (lifecycle *Lifecycle) ReadinessReport(ctx context.Context) Report {
+	if lifecycle.Draining() {
+		return Report{Result: Failure, Err: ErrDraining}
+	}
+
+	return readinessReporter(lifecycle.probes)(ctx)
+}
+
+// StartupReport возвращает отчёт Startup-пробы
+// исходных проб, включая результаты их проверок.
+func (lifecycle *Lifecycle) StartupReport(ctx context.Context) Report {
+	return startupReporter(lifecycle.probes)(ctx)
+}
+
+// LivenessCheck выполняет именованную проверку
+// Liveness-пробы исходных проб, если они её
+// поддерживают.
+//
+//	Смотри CompositeProbes.LivenessCheck
+func (lifecycle *Lifecycle) LivenessCheck(ctx context.Context, name string) (CheckResult, bool) {
+	return livenessCheckRunner(lifecycle.probes)(ctx, name)
+}
+
+// ReadinessCheck выполняет именованную проверку
+// Readiness-пробы исходных проб. Во время завершения
+// работы результатом проверки становится Failure с
+// ошибкой ErrDraining.
+//
+//	Смотри CompositeProbes.ReadinessCheck
+func (lifecycle *Lifecycle) ReadinessCheck(ctx context.Context, name string) (CheckResult, bool) {
+	check, found := readinessCheckRunner(lifecycle.probes)(ctx, name)
+	if found && lifecycle.Draining() {
+		check.Result, check.Err = Failure, ErrDraining
+	}
+
+	return check, found
+}
+
+// StartupCheck выполняет именованную проверку
+// Startup-пробы исходных проб, если они её
+// поддерживают.
+//
+//	Смотри CompositeProbes.StartupCheck
+func (lifecycle *Lifecycle) StartupCheck(ctx context.Context, name string) (CheckResult, bool) {
+	return startupCheckRunner(lifecycle.probes)(ctx, name)
+}
+
+// OnShutdown добавляет обработчик завершения работы.
+//
+// Обработчики выполняются в порядке добавления после
+// ожидания WithPropagationDelay.
+func (lifecycle *Lifecycle) OnShutdown(hook func(context.Context) error) *Lifecycle {
+	lifecycle.mu.Lock()
+	defer lifecycle.mu.Unlock()
+
+	lifecycle.hooks = append(lifecycle.hooks, hook)
+
+	return lifecycle
+}
+
+// Draining возвращает true, если завершение работы
+// уже началось.
+func (lifecycle *Lifecycle) Draining() bool {
+	lifecycle.mu.RLock()
+	defer lifecycle.mu.RUnlock()
+
+	return lifecycle.draining
+}
+
+// Run ожидает сигнал завершения или отмену ctx, после
+// чего выполняет Lifecycle.Drain.
+func (lifecycle *Lifecycle) Run(ctx context.Context) error {
+	signals, stop := signal.NotifyContext(ctx, lifecycle.signals...)
+	defer stop()
+
+	select {
+	case <-signals.Done():
+	case <-lifecycle.done:
+	}
+
+	return lifecycle.Drain(context.Background())
+}
+
+// Drain выполняет завершение работы.
+//
+// Все обработчики завершения выполняются, даже если
+// некоторые из них вернули ошибку; метод возвращает
+// первую из ошибок. Повторные вызовы дожидаются
+// завершения первого и возвращают его результат.
+//
+// Отмена ctx прерывает ожидание WithPropagationDelay.
+func (lifecycle *Lifecycle) Drain(ctx context.Context) error {
+	lifecycle.once.Do(func() {
+		defer close(lifecycle.done)
+
+		lifecycle.err = lifecycle.drain(ctx)
+	})
+
+	<-lifecycle.done
+
+	return lifecycle.err
+}
+
+func (lifecycle *Lifecycle) drain(ctx context.Context) error {
+	lifecycle.mu.Lock()
+	lifecycle.draining = true
+	hooks := append([]func(context.Context) error(nil), lifecycle.hooks...)
+	lifecycle.mu.Unlock()
+
+	timer := time.NewTimer(lifecycle.delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+	case <-ctx.Done():
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), lifecycle.timeout)
+	defer cancel()
+
+	var first error
+
+	for _, hook := range hooks {
+		if err := hook(ctx); err != nil && first == nil {
+			first = err
+		}
+	}
+
+	if lifecycle.server != nil {
+		if err := lifecycle.server.Shutdown(ctx); err != nil && first == nil {
+			first = err
+		}
+	}
+
+	return first
+}
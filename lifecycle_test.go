@@ -0,0 +1,217 @@
+package probes
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testShutdowner struct {
+	calls *[]string
+	err   error
+}
+
+func (server testShutdowner) Shutdown(context.Context) error {
+	*server.calls = append(*server.calls, "server")
+
+	return server.err
+}
+
+func TestLifecycle_Drain(t *testing.T) {
+	t.Parallel()
+
+	// Arrange.
+	var calls []string
+
+	errHook := errors.New("probes: hook error")
+
+	lifecycle := NewLifecycle(DefaultProbes,
+		WithPropagationDelay(0),
+		WithServer(testShutdowner{calls: &calls, err: errDummyProbe}),
+	).
+		OnShutdown(func(context.Context) error {
+			calls = append(calls, "first")
+
+			return errHook
+		}).
+		OnShutdown(func(context.Context) error {
+			calls = append(calls, "second")
+
+			return nil
+		})
+
+	ctx := context.Background()
+
+	result, err := lifecycle.Readiness(ctx)
+	require.NoError(t, err)
+	require.Equal(t, Success, result)
+
+	// Act.
+	err = lifecycle.Drain(ctx)
+
+	// Assert.
+	assert.ErrorIs(t, err, errHook)
+	assert.Equal(t, []string{"first", "second", "server"}, calls)
+	assert.True(t, lifecycle.Draining())
+
+	result, err = lifecycle.Readiness(ctx)
+	assert.Equal(t, Failure, result)
+	assert.ErrorIs(t, err, ErrDraining)
+
+	result, err = lifecycle.Liveness(ctx)
+	assert.Equal(t, Success, result)
+	assert.NoError(t, err)
+
+	result, err = lifecycle.Startup(ctx)
+	assert.Equal(t, Success, result)
+	assert.NoError(t, err)
+
+	assert.ErrorIs(t, lifecycle.Drain(ctx), errHook)
+	assert.Equal(t, []string{"first", "second", "server"}, calls)
+}
+
+func TestLifecycle_Drain_PropagationDelay(t *testing.T) {
+	t.Parallel()
+
+	// Arrange.
+	hooked := make(chan struct{})
+
+	lifecycle := NewLifecycle(DefaultProbes, WithPropagationDelay(time.Hour)).
+		OnShutdown(func(context.Context) error {
+			close(hooked)
+
+			return nil
+		})
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	done := make(chan error, 1)
+
+	// Act.
+	go func() {
+		done <- lifecycle.Drain(ctx)
+	}()
+
+	// Assert.
+	assert.Eventually(t, lifecycle.Draining, time.Second, 10*time.Millisecond)
+
+	select {
+	case <-hooked:
+		t.Fatal("shutdown hook called before propagation delay")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	cancel()
+
+	assert.NoError(t, <-done)
+	assert.True(t, isClosed(hooked))
+}
+
+func isClosed(ch chan struct{}) bool {
+	select {
+	case <-ch:
+		return true
+	default:
+		return false
+	}
+}
+
+func TestLifecycle_Run(t *testing.T) {
+	t.Parallel()
+
+	// Arrange.
+	lifecycle := NewLifecycle(DefaultProbes, WithPropagationDelay(0), WithSignals(syscall.SIGHUP))
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	done := make(chan error, 1)
+
+	// Act.
+	go func() {
+		done <- lifecycle.Run(ctx)
+	}()
+
+	time.Sleep(50 * time.Millisecond)
+
+	assert.False(t, lifecycle.Draining())
+
+	cancel()
+
+	// Assert.
+	assert.NoError(t, <-done)
+	assert.True(t, lifecycle.Draining())
+}
+
+func TestLifecycle_FiberServer(t *testing.T) {
+	t.Parallel()
+
+	// Arrange.
+	app := fiber.New(fiber.Config{DisableStartupMessage: true})
+
+	server := Fiber(app)
+	lifecycle := NewLifecycle(DefaultProbes, WithPropagationDelay(0), WithServer(server))
+
+	server.Probes(lifecycle)
+
+	// Act.
+	require.NoError(t, lifecycle.Drain(context.Background()))
+
+	// Assert.
+	response, err := app.Test(httptest.NewRequest(fiber.MethodGet, DefaultReadinessPath, nil))
+	require.NoError(t, err)
+
+	assert.Equal(t, fiber.StatusInternalServerError, response.StatusCode)
+
+	response, err = app.Test(httptest.NewRequest(fiber.MethodGet, DefaultLivenessPath, nil))
+	require.NoError(t, err)
+
+	assert.Equal(t, fiber.StatusOK, response.StatusCode)
+}
+
+func TestLifecycle_Handler(t *testing.T) {
+	t.Parallel()
+
+	// Arrange.
+	composite := NewCompositeProbes().
+		RegisterReadiness("postgres", testProbe(Success, nil)).
+		RegisterReadiness("redis", testProbe(Warning, errDummyProbe))
+
+	lifecycle := NewLifecycle(composite, WithPropagationDelay(0))
+
+	server := NewHTTPServer().Probes(lifecycle)
+
+	serve := func(target string) (int, string) {
+		recorder := httptest.NewRecorder()
+
+		server.Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, target, nil))
+
+		return recorder.Code, recorder.Body.String()
+	}
+
+	// Act.
+	verboseStatus, verboseBody := serve("/readiness?verbose&exclude=redis")
+	checkStatus, _ := serve("/readiness/postgres")
+	unknownStatus, _ := serve("/readiness/kafka")
+
+	require.NoError(t, lifecycle.Drain(context.Background()))
+
+	drainingStatus, drainingBody := serve("/readiness/postgres")
+
+	// Assert.
+	assert.Equal(t, http.StatusOK, verboseStatus)
+	assert.Equal(t, "[+]postgres ok\nreadiness check passed\n", verboseBody)
+
+	assert.Equal(t, http.StatusOK, checkStatus)
+	assert.Equal(t, http.StatusNotFound, unknownStatus)
+
+	assert.Equal(t, http.StatusInternalServerError, drainingStatus)
+	assert.Equal(t, "postgres: probes: draining", drainingBody)
+}
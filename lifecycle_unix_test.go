@@ -0,0 +1,33 @@
+//go:build !windows
+
+package probes
+
+import (
+	"context"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLifecycle_Run_Signal(t *testing.T) {
+	// Arrange.
+	lifecycle := NewLifecycle(DefaultProbes, WithPropagationDelay(0), WithSignals(syscall.SIGUSR2))
+
+	done := make(chan error, 1)
+
+	go func() {
+		done <- lifecycle.Run(context.Background())
+	}()
+
+	time.Sleep(50 * time.Millisecond)
+
+	// Act.
+	require.NoError(t, syscall.Kill(syscall.Getpid(), syscall.SIGUSR2))
+
+	// Assert.
+	assert.NoError(t, <-done)
+	assert.True(t, lifecycle.Draining())
+}
@@ -0,0 +1,308 @@
+package probes
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const (
+	// DefaultMetricsPath содержит путь по умолчанию
+	// эндпоинта метрик Prometheus.
+	//
+	//	Смотри FiberServer.Metrics
+	DefaultMetricsPath = "/metrics"
+
+	// DefaultMetricsNamespace содержит префикс имён
+	// метрик по умолчанию.
+	DefaultMetricsNamespace = "probes"
+
+	// MetricsContentType содержит тип тела ответа
+	// эндпоинта метрик: текстовый формат Prometheus.
+	MetricsContentType = "text/plain; version=0.0.4; charset=utf-8"
+)
+
+// DefaultMetricsBuckets содержит границы корзин
+// гистограмм длительности по умолчанию в секундах.
+var DefaultMetricsBuckets = []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+
+// MetricsOption настраивает Metrics.
+type MetricsOption func(*Metrics)
+
+// WithMetricsNamespace задаёт префикс имён метрик.
+//
+// По умолчанию используется DefaultMetricsNamespace.
+func WithMetricsNamespace(namespace string) MetricsOption {
+	return func(metrics *Metrics) {
+		metrics.namespace = namespace
+	}
+}
+
+// WithMetricsBuckets задаёт границы корзин гистограмм
+// длительности в секундах в порядке возрастания.
+//
+// По умолчанию используется DefaultMetricsBuckets.
+func WithMetricsBuckets(buckets ...float64) MetricsOption {
+	return func(metrics *Metrics) {
+		metrics.buckets = buckets
+	}
+}
+
+// Metrics собирает метрики проб и их именованных
+// проверок и отдаёт их в текстовом формате Prometheus
+// без зависимости от клиентской библиотеки:
+//
+//	probes_probe_result{probe, result}                     – текущий результат пробы (1 или 0)
+//	probes_probe_transitions_total{probe, from, to}        – число смен результата пробы
+//	probes_probe_duration_seconds{probe}                   – гистограмма длительности пробы
+//	probes_check_result{probe, check, result}              – текущий результат проверки
+//	probes_check_transitions_total{probe, check, from, to} – число смен результата проверки
+//	probes_check_duration_seconds{probe, check}            – гистограмма длительности проверки
+//
+// Метрики обновляются HTTP-обработчиками, настроенными
+// параметром WithMetrics, либо вызовом Metrics.Observe.
+//
+// Для инициализации необходимо использовать метод
+// NewMetrics.
+type Metrics struct {
+	namespace string
+	buckets   []float64
+
+	mu     sync.Mutex
+	probes map[metricsKey]*metricsSeries
+	checks map[metricsKey]*metricsSeries
+}
+
+// NewMetrics инициализирует пустой сборщик метрик.
+func NewMetrics(options ...MetricsOption) *Metrics {
+	metrics := &Metrics{
+		namespace: DefaultMetricsNamespace,
+		buckets:   DefaultMetricsBuckets,
+		probes:    make(map[metricsKey]*metricsSeries),
+		checks:    make(map[metricsKey]*metricsSeries),
+	}
+
+	for _, option := range options {
+		option(metrics)
+	}
+
+	return metrics
+}
+
+// Observe учитывает отчёт о выполнении пробы и
+// результаты всех её проверок.
+func (metrics *Metrics) Observe(report Report) {
+	metrics.mu.Lock()
+	defer metrics.mu.Unlock()
+
+	metrics.series(metrics.probes, metricsKey{probe: report.Probe}).
+		observe(report.Result, report.Duration.Seconds(), metrics.buckets)
+
+	for _, check := range report.Checks {
+		metrics.series(metrics.checks, metricsKey{probe: report.Probe, check: check.Name}).
+			observe(check.Result, check.Duration.Seconds(), metrics.buckets)
+	}
+}
+
+// WriteTo записывает метрики в текстовом формате
+// Prometheus.
+//
+// Метрики формируются в памяти и записываются в w уже
+// после снятия блокировки, поэтому медленный клиент не
+// задерживает Observe и пробы.
+func (metrics *Metrics) WriteTo(w io.Writer) (int64, error) {
+	return metrics.render().WriteTo(w)
+}
+
+// ServeHTTP отдаёт метрики в текстовом формате
+// Prometheus, например для подключения к существующему
+// маршрутизатору net/http.
+func (metrics *Metrics) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", MetricsContentType)
+
+	_, _ = metrics.WriteTo(w)
+}
+
+func (metrics *Metrics) render() *metricsBuffer {
+	metrics.mu.Lock()
+	defer metrics.mu.Unlock()
+
+	buffer := &metricsBuffer{}
+
+	metrics.write(buffer, "probe", metrics.probes, false)
+	metrics.write(buffer, "check", metrics.checks, true)
+
+	return buffer
+}
+
+func (metrics *Metrics) series(all map[metricsKey]*metricsSeries, key metricsKey) *metricsSeries {
+	series, found := all[key]
+	if !found {
+		series = &metricsSeries{
+			transitions: make(map[[2]Result]uint64),
+			buckets:     make([]uint64, len(metrics.buckets)),
+		}
+		all[key] = series
+	}
+
+	return series
+}
+
+func (metrics *Metrics) write(buffer *metricsBuffer, kind string, all map[metricsKey]*metricsSeries, named bool) {
+	if len(all) == 0 {
+		return
+	}
+
+	keys := make([]metricsKey, 0, len(all))
+	for key := range all {
+		keys = append(keys, key)
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].probe != keys[j].probe {
+			return keys[i].probe < keys[j].probe
+		}
+
+		return keys[i].check < keys[j].check
+	})
+
+	name := metrics.namespace + "_" + kind
+
+	buffer.header(name+"_result", "Current result of the "+kind+", 1 for the active result.", "gauge")
+
+	for _, key := range keys {
+		for _, result := range []Result{Success, Warning, Failure, Unknown} {
+			value := "0"
+			if all[key].result == result {
+				value = "1"
+			}
+
+			buffer.sample(name+"_result", key.labels(named, "result", result.String()), value)
+		}
+	}
+
+	buffer.header(name+"_transitions_total", "Number of "+kind+" result transitions.", "counter")
+
+	for _, key := range keys {
+		transitions := make([][2]Result, 0, len(all[key].transitions))
+		for transition := range all[key].transitions {
+			transitions = append(transitions, transition)
+		}
+
+		sort.Slice(transitions, func(i, j int) bool {
+			if transitions[i][0] != transitions[j][0] {
+				return transitions[i][0] < transitions[j][0]
+			}
+
+			return transitions[i][1] < transitions[j][1]
+		})
+
+		for _, transition := range transitions {
+			labels := key.labels(named, "from", transition[0].String(), "to", transition[1].String())
+			buffer.sample(name+"_transitions_total", labels, strconv.FormatUint(all[key].transitions[transition], 10))
+		}
+	}
+
+	buffer.header(name+"_duration_seconds", "Duration of the "+kind+" in seconds.", "histogram")
+
+	for _, key := range keys {
+		series := all[key]
+
+		for i, bound := range metrics.buckets {
+			labels := key.labels(named, "le", formatFloat(bound))
+			buffer.sample(name+"_duration_seconds_bucket", labels, strconv.FormatUint(series.buckets[i], 10))
+		}
+
+		buffer.sample(name+"_duration_seconds_bucket", key.labels(named, "le", "+Inf"), strconv.FormatUint(series.count, 10))
+		buffer.sample(name+"_duration_seconds_sum", key.labels(named), formatFloat(series.sum))
+		buffer.sample(name+"_duration_seconds_count", key.labels(named), strconv.FormatUint(series.count, 10))
+	}
+}
+
+type metricsKey struct {
+	probe string
+	check string
+}
+
+func (key metricsKey) labels(named bool, pairs ...string) string {
+	labels := []string{"probe", key.probe}
+	if named {
+		labels = append(labels, "check", key.check)
+	}
+
+	labels = append(labels, pairs...)
+
+	var builder strings.Builder
+
+	builder.WriteByte('{')
+
+	for i := 0; i < len(labels); i += 2 {
+		if i > 0 {
+			builder.WriteByte(',')
+		}
+
+		builder.WriteString(labels[i] + `="` + escapeLabel(labels[i+1]) + `"`)
+	}
+
+	builder.WriteByte('}')
+
+	return builder.String()
+}
+
+type metricsSeries struct {
+	observed    bool
+	result      Result
+	transitions map[[2]Result]uint64
+
+	buckets []uint64
+	count   uint64
+	sum     float64
+}
+
+// observe учитывает результат и длительность. Первое
+// наблюдение не считается сменой результата, а
+// неподдерживаемый результат учитывается как Failure.
+func (series *metricsSeries) observe(result Result, seconds float64, bounds []float64) {
+	result = Aggregate(result)
+
+	if series.observed && series.result != result {
+		series.transitions[[2]Result{series.result, result}]++
+	}
+
+	series.observed, series.result = true, result
+
+	for i, bound := range bounds {
+		if seconds <= bound {
+			series.buckets[i]++
+		}
+	}
+
+	series.count++
+	series.sum += seconds
+}
+
+type metricsBuffer struct {
+	bytes.Buffer
+}
+
+func (buffer *metricsBuffer) header(name, help, kind string) {
+	buffer.WriteString("# HELP " + name + " " + help + "\n# TYPE " + name + " " + kind + "\n")
+}
+
+func (buffer *metricsBuffer) sample(name, labels, value string) {
+	buffer.WriteString(name + labels + " " + value + "\n")
+}
+
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func escapeLabel(value string) string {
+	return labelEscaper.Replace(value)
+}
+
+func formatFloat(value float64) string {
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
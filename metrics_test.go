@@ -0,0 +1,189 @@
+package probes
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetrics_WriteTo(t *testing.T) {
+	t.Parallel()
+
+	// Arrange.
+	metrics := NewMetrics(WithMetricsNamespace("app"), WithMetricsBuckets(0.01, 0.1))
+
+	metrics.Observe(Report{
+		Probe:    readinessProbe,
+		Result:   Success,
+		Checks:   CheckResults{{Name: `post"gres`, Result: Success, Duration: 5 * time.Millisecond}},
+		Duration: 5 * time.Millisecond,
+	})
+	metrics.Observe(Report{
+		Probe:    readinessProbe,
+		Result:   Failure,
+		Checks:   CheckResults{{Name: `post"gres`, Result: Failure, Duration: 50 * time.Millisecond}},
+		Duration: 50 * time.Millisecond,
+	})
+
+	var buffer bytes.Buffer
+
+	// Act.
+	written, err := metrics.WriteTo(&buffer)
+
+	// Assert.
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(buffer.Len()), written)
+	assert.Equal(t, `# HELP app_probe_result Current result of the probe, 1 for the active result.
+# TYPE app_probe_result gauge
+app_probe_result{probe="readiness",result="success"} 0
+app_probe_result{probe="readiness",result="warning"} 0
+app_probe_result{probe="readiness",result="failure"} 1
+app_probe_result{probe="readiness",result="unknown"} 0
+# HELP app_probe_transitions_total Number of probe result transitions.
+# TYPE app_probe_transitions_total counter
+app_probe_transitions_total{probe="readiness",from="success",to="failure"} 1
+# HELP app_probe_duration_seconds Duration of the probe in seconds.
+# TYPE app_probe_duration_seconds histogram
+app_probe_duration_seconds_bucket{probe="readiness",le="0.01"} 1
+app_probe_duration_seconds_bucket{probe="readiness",le="0.1"} 2
+app_probe_duration_seconds_bucket{probe="readiness",le="+Inf"} 2
+app_probe_duration_seconds_sum{probe="readiness"} 0.055
+app_probe_duration_seconds_count{probe="readiness"} 2
+# HELP app_check_result Current result of the check, 1 for the active result.
+# TYPE app_check_result gauge
+app_check_result{probe="readiness",check="post\"gres",result="success"} 0
+app_check_result{probe="readiness",check="post\"gres",result="warning"} 0
+app_check_result{probe="readiness",check="post\"gres",result="failure"} 1
+app_check_result{probe="readiness",check="post\"gres",result="unknown"} 0
+# HELP app_check_transitions_total Number of check result transitions.
+# TYPE app_check_transitions_total counter
+app_check_transitions_total{probe="readiness",check="post\"gres",from="success",to="failure"} 1
+# HELP app_check_duration_seconds Duration of the check in seconds.
+# TYPE app_check_duration_seconds histogram
+app_check_duration_seconds_bucket{probe="readiness",check="post\"gres",le="0.01"} 1
+app_check_duration_seconds_bucket{probe="readiness",check="post\"gres",le="0.1"} 2
+app_check_duration_seconds_bucket{probe="readiness",check="post\"gres",le="+Inf"} 2
+app_check_duration_seconds_sum{probe="readiness",check="post\"gres"} 0.055
+app_check_duration_seconds_count{probe="readiness",check="post\"gres"} 2
+`, buffer.String())
+}
+
+func TestMetrics_WriteTo_Empty(t *testing.T) {
+	t.Parallel()
+
+	// Arrange.
+	var buffer bytes.Buffer
+
+	// Act.
+	written, err := NewMetrics().WriteTo(&buffer)
+
+	// Assert.
+	assert.NoError(t, err)
+	assert.Zero(t, written)
+}
+
+type testStalledWriter struct {
+	started chan struct{}
+	release chan struct{}
+}
+
+func (writer testStalledWriter) Write(p []byte) (int, error) {
+	close(writer.started)
+	<-writer.release
+
+	return len(p), nil
+}
+
+func TestMetrics_WriteTo_Stalled(t *testing.T) {
+	t.Parallel()
+
+	// Arrange.
+	metrics := NewMetrics()
+	metrics.Observe(Report{Probe: readinessProbe, Result: Success})
+
+	writer := testStalledWriter{started: make(chan struct{}), release: make(chan struct{})}
+	defer close(writer.release)
+
+	go func() {
+		_, _ = metrics.WriteTo(writer)
+	}()
+
+	<-writer.started
+
+	observed := make(chan struct{})
+
+	// Act.
+	go func() {
+		metrics.Observe(Report{Probe: readinessProbe, Result: Failure})
+		close(observed)
+	}()
+
+	// Assert.
+	select {
+	case <-observed:
+	case <-time.After(time.Second):
+		t.Fatal("Observe is blocked by a stalled metrics writer")
+	}
+}
+
+func TestFiberServer_Metrics(t *testing.T) {
+	t.Parallel()
+
+	// Arrange.
+	app := fiber.New()
+
+	metrics := NewMetrics()
+
+	probes := NewCompositeProbes().
+		RegisterReadiness("postgres", testProbe(Success, nil))
+
+	Fiber(app).Probes(probes, WithMetrics(metrics)).Metrics(metrics)
+
+	for _, target := range []string{DefaultReadinessPath, DefaultReadinessPath + "?exclude=postgres", DefaultReadinessPath + "/postgres"} {
+		_, err := app.Test(httptest.NewRequest(fiber.MethodGet, target, nil))
+		require.NoError(t, err)
+	}
+
+	// Act.
+	response, err := app.Test(httptest.NewRequest(fiber.MethodGet, DefaultMetricsPath, nil))
+
+	// Assert.
+	require.NoError(t, err)
+
+	body, err := io.ReadAll(response.Body)
+	require.NoError(t, err)
+
+	assert.Equal(t, fiber.StatusOK, response.StatusCode)
+	assert.Equal(t, MetricsContentType, response.Header.Get(fiber.HeaderContentType))
+	assert.Contains(t, string(body), `probes_probe_result{probe="readiness",result="success"} 1`)
+	assert.Contains(t, string(body), `probes_check_duration_seconds_count{probe="readiness",check="postgres"} 1`)
+	assert.NotContains(t, string(body), `probe="liveness"`)
+}
+
+func TestHTTPServer_Metrics(t *testing.T) {
+	t.Parallel()
+
+	// Arrange.
+	metrics := NewMetrics()
+
+	server := NewHTTPServer().Probes(DefaultProbes, WithMetrics(metrics)).Metrics(metrics)
+
+	server.Handler().ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, DefaultLivenessPath, nil))
+
+	recorder := httptest.NewRecorder()
+
+	// Act.
+	server.Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, DefaultMetricsPath, nil))
+
+	// Assert.
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), `probes_probe_duration_seconds_count{probe="liveness"} 1`)
+}
@@ -0,0 +1,415 @@
+package probes
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// NewHTTPServer подготавливает REST-сервер на стандартной
+// библиотеке net/http для приёма Liveness-, Readiness- и
+// Startup-проб.
+//
+// После вызова метода следует вызвать метод
+// HTTPServer.Probes, который выполнит инициализацию
+// REST-эндпоинтов.
+//
+//	Смотри ServerOption
+func NewHTTPServer(options ...ServerOption) *HTTPServer {
+	mux := http.NewServeMux()
+
+	return &HTTPServer{
+		mux:    mux,
+		server: &http.Server{Handler: mux},
+		config: newServerConfig(options),
+	}
+}
+
+// HTTPServer содержит REST-эндпоинты для проб
+// Kubernetes на net/http: Liveness, Readiness и Startup.
+//
+// Эндпоинты ведут себя так же, как эндпоинты
+// FiberServer.
+//
+// Для инициализации сервера необходимо сначала
+// вызвать метод NewHTTPServer с необходимыми параметрами.
+type HTTPServer struct {
+	mux    *http.ServeMux
+	server *http.Server
+	config serverConfig
+
+	probes Probes
+}
+
+// Probes инициализирует REST-эндпоинты для Liveness-,
+// Readiness- и Startup-проб Kubernetes.
+//
+//	Смотри FiberServer.Probes
+func (server *HTTPServer) Probes(probes Probes, options ...HandlerOption) *HTTPServer {
+	if !server.config.livenessDisabled {
+		liveness := NewHTTPLiveness(probes, options...)
+		server.handle(server.config.livenessPath, liveness, http.HandlerFunc(liveness.Check))
+	}
+
+	if !server.config.readinessDisabled {
+		readiness := NewHTTPReadiness(probes, options...)
+		server.handle(server.config.readinessPath, readiness, http.HandlerFunc(readiness.Check))
+	}
+
+	if !server.config.startupDisabled {
+		startup := NewHTTPStartup(probes, options...)
+		server.handle(server.config.startupPath, startup, http.HandlerFunc(startup.Check))
+	}
+
+	server.probes = probes
+
+	return server
+}
+
+// Override инициализирует административный эндпоинт
+// переопределения Readiness-пробы.
+//
+//	Смотри FiberServer.Override
+func (server *HTTPServer) Override(override *Override, token string) *HTTPServer {
+	server.mux.HandleFunc(server.config.path(server.config.overridePath), func(w http.ResponseWriter, r *http.Request) {
+		if !authorized(token, r.Header.Get("Authorization")) {
+			sendHTTP(w, unauthorized())
+
+			return
+		}
+
+		// MaxBytesReader возвращает ошибку при превышении
+		// размера, а другие ошибки чтения означают разрыв
+		// соединения, после которого ответ не важен.
+		body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, overrideBodyLimit))
+		if err != nil {
+			sendHTTP(w, overrideTooLarge())
+
+			return
+		}
+
+		sendHTTP(w, override.serve(token, r.Method, r.Header.Get("Authorization"), body))
+	})
+
+	return server
+}
+
+// Metrics инициализирует эндпоинт метрик Prometheus.
+//
+//	Смотри FiberServer.Metrics
+func (server *HTTPServer) Metrics(metrics *Metrics) *HTTPServer {
+	server.mux.Handle(server.config.path(server.config.metricsPath), metrics)
+
+	return server
+}
+
+// Handler возвращает http.Handler со всеми эндпоинтами
+// проб, например для подключения к существующему
+// маршрутизатору.
+func (server *HTTPServer) Handler() http.Handler {
+	return server.mux
+}
+
+// Start запускает REST-сервер с пробами Kubernetes
+// по указанному адресу.
+//
+// После вызова HTTPServer.Shutdown метод возвращает nil.
+func (server *HTTPServer) Start(address string) error {
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return err
+	}
+
+	return server.serve(listener)
+}
+
+// Listen запускает REST-сервер с пробами Kubernetes
+// по адресу, заданному WithAddress.
+//
+// По умолчанию используется DefaultServerAddress.
+func (server *HTTPServer) Listen() error {
+	return server.Start(server.config.address)
+}
+
+// StartUnix запускает REST-сервер с пробами Kubernetes
+// на unix-сокете с правами доступа mode.
+//
+//	Смотри FiberServer.StartUnix
+func (server *HTTPServer) StartUnix(path string, mode os.FileMode) error {
+	listener, err := listenUnix(path, mode)
+	if err != nil {
+		return err
+	}
+
+	return server.serve(listener)
+}
+
+// Shutdown корректно останавливает REST-сервер,
+// дожидаясь завершения активных запросов.
+func (server *HTTPServer) Shutdown(ctx context.Context) error {
+	return server.server.Shutdown(ctx)
+}
+
+func (server *HTTPServer) serve(listener net.Listener) error {
+	err := server.server.Serve(listener)
+	if errors.Is(err, http.ErrServerClosed) {
+		return nil
+	}
+
+	return err
+}
+
+func (server *HTTPServer) handle(path string, probe, check http.Handler) {
+	path = server.config.path(path)
+
+	prefix := strings.TrimSuffix(path, "/") + "/"
+
+	server.mux.Handle(path, server.methods(probe))
+	server.mux.Handle(prefix, segment(prefix, server.methods(check)))
+}
+
+// segment пропускает к handler только запросы, путь
+// которых содержит ровно один сегмент после prefix, как
+// маршрут /:check в Fiber. На остальные запросы
+// отвечает HTTP 404 Not Found.
+func segment(prefix string, handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		name := strings.TrimPrefix(r.URL.EscapedPath(), prefix)
+		if name == "" || strings.Contains(name, "/") {
+			http.NotFound(w, r)
+
+			return
+		}
+
+		handler.ServeHTTP(w, r)
+	})
+}
+
+func (server *HTTPServer) methods(handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for _, method := range server.config.methods {
+			if r.Method == method {
+				handler.ServeHTTP(w, r)
+
+				return
+			}
+		}
+
+		w.Header().Set("Allow", strings.Join(server.config.methods, ", "))
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+	})
+}
+
+// DefaultHTTPLiveness содержит инициализированный
+// HTTP-обработчик Liveness-запроса Kubernetes.
+//
+// Обработчик всегда возвращает HTTP 200 OK.
+var DefaultHTTPLiveness = NewHTTPLiveness(DefaultLiveness)
+
+// HTTPLiveness обрабатывает HTTP-запросы Liveness
+// Kubernetes на net/http.
+//
+// Обработчику необходима реализация Liveness.
+//
+// Для инициализации необходимо использовать метод
+// NewHTTPLiveness.
+// Реализация по умолчанию – DefaultHTTPLiveness.
+type HTTPLiveness struct {
+	probe  Liveness
+	config handlerConfig
+}
+
+// ServeHTTP обрабатывает HTTP-запрос Liveness от
+// Kubernetes.
+//
+//	Смотри FiberLiveness.Liveness
+func (handler HTTPLiveness) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	serveHTTP(w, r, handler.config, livenessReporter(handler.probe))
+}
+
+// Check обрабатывает HTTP-запрос именованной проверки
+// Liveness-пробы, имя которой передаётся последним
+// сегментом пути.
+//
+//	Смотри FiberLiveness.Check
+func (handler HTTPLiveness) Check(w http.ResponseWriter, r *http.Request) {
+	serveHTTPCheck(w, r, handler.config, livenessCheckReporter(handler.probe))
+}
+
+// NewHTTPLiveness инициализирует HTTP-обработчик
+// Liveness-запросов Kubernetes на net/http.
+//
+//	Смотри HandlerOption
+func NewHTTPLiveness(probe Liveness, options ...HandlerOption) HTTPLiveness {
+	return HTTPLiveness{probe: probe, config: newHandlerConfig(options)}
+}
+
+// DefaultHTTPReadiness содержит инициализированный
+// HTTP-обработчик Readiness-запроса Kubernetes.
+//
+// Обработчик всегда возвращает HTTP 200 OK.
+var DefaultHTTPReadiness = NewHTTPReadiness(DefaultReadiness)
+
+// HTTPReadiness обрабатывает HTTP-запросы Readiness
+// Kubernetes на net/http.
+//
+// Обработчику необходима реализация Readiness.
+//
+// Для инициализации необходимо использовать метод
+// NewHTTPReadiness.
+// Реализация по умолчанию – DefaultHTTPReadiness.
+type HTTPReadiness struct {
+	probe  Readiness
+	config handlerConfig
+}
+
+// ServeHTTP обрабатывает HTTP-запрос Readiness от
+// Kubernetes.
+//
+//	Смотри FiberReadiness.Readiness
+func (handler HTTPReadiness) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	serveHTTP(w, r, handler.config, readinessReporter(handler.probe))
+}
+
+// Check обрабатывает HTTP-запрос именованной проверки
+// Readiness-пробы, имя которой передаётся последним
+// сегментом пути.
+//
+//	Смотри FiberReadiness.Check
+func (handler HTTPReadiness) Check(w http.ResponseWriter, r *http.Request) {
+	serveHTTPCheck(w, r, handler.config, readinessCheckReporter(handler.probe))
+}
+
+// NewHTTPReadiness инициализирует HTTP-обработчик
+// Readiness-запросов Kubernetes на net/http.
+//
+//	Смотри HandlerOption
+func NewHTTPReadiness(probe Readiness, options ...HandlerOption) HTTPReadiness {
+	return HTTPReadiness{probe: probe, config: newHandlerConfig(options)}
+}
+
+// DefaultHTTPStartup содержит инициализированный
+// HTTP-обработчик Startup-запроса Kubernetes.
+//
+// Обработчик всегда возвращает HTTP 200 OK.
+var DefaultHTTPStartup = NewHTTPStartup(DefaultStartup)
+
+// HTTPStartup обрабатывает HTTP-запросы Startup
+// Kubernetes на net/http.
+//
+// Обработчику необходима реализация Startup.
+//
+// Для инициализации необходимо использовать метод
+// NewHTTPStartup.
+// Реализация по умолчанию – DefaultHTTPStartup.
+type HTTPStartup struct {
+	probe  Startup
+	config handlerConfig
+}
+
+// ServeHTTP обрабатывает HTTP-запрос Startup от
+// Kubernetes.
+//
+//	Смотри FiberStartup.Startup
+func (handler HTTPStartup) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	serveHTTP(w, r, handler.config, startupReporter(handler.probe))
+}
+
+// Check обрабатывает HTTP-запрос именованной проверки
+// Startup-пробы, имя которой передаётся последним
+// сегментом пути.
+//
+//	Смотри FiberStartup.Check
+func (handler HTTPStartup) Check(w http.ResponseWriter, r *http.Request) {
+	serveHTTPCheck(w, r, handler.config, startupCheckReporter(handler.probe))
+}
+
+// NewHTTPStartup инициализирует HTTP-обработчик
+// Startup-запросов Kubernetes на net/http.
+//
+//	Смотри HandlerOption
+func NewHTTPStartup(probe Startup, options ...HandlerOption) HTTPStartup {
+	return HTTPStartup{probe: probe, config: newHandlerConfig(options)}
+}
+
+func httpRequest(r *http.Request) request {
+	query := r.URL.Query()
+
+	_, verbose := query[verboseParam]
+
+	request := request{
+		accept:  r.Header.Get("Accept"),
+		verbose: verbose,
+	}
+
+	for _, exclude := range query[excludeParam] {
+		request.exclude = append(request.exclude, strings.Split(exclude, ",")...)
+	}
+
+	return request
+}
+
+func serveHTTP(w http.ResponseWriter, r *http.Request, config handlerConfig, reporter reporter) {
+	request := httpRequest(r)
+
+	report := reporter(request.context(r.Context()))
+	config.observe(request, report)
+
+	response, err := config.respond(request, report)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+
+		return
+	}
+
+	sendHTTP(w, response)
+}
+
+func serveHTTPCheck(w http.ResponseWriter, r *http.Request, config handlerConfig, reporter checkReporter) {
+	request := httpRequest(r)
+
+	name, err := url.PathUnescape(path.Base(r.URL.EscapedPath()))
+	if err != nil {
+		sendHTTP(w, unknownCheck(path.Base(r.URL.EscapedPath())))
+
+		return
+	}
+
+	report, found := reporter(request.context(r.Context()), name)
+	if !found {
+		sendHTTP(w, unknownCheck(name))
+
+		return
+	}
+
+	response, err := config.respond(request, report)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+
+		return
+	}
+
+	sendHTTP(w, response)
+}
+
+func sendHTTP(w http.ResponseWriter, response response) {
+	if response.contentType != "" {
+		w.Header().Set("Content-Type", response.contentType)
+	}
+
+	for header, value := range response.headers {
+		w.Header().Set(header, value)
+	}
+
+	w.Header().Set("Content-Length", strconv.Itoa(len(response.body)))
+	w.WriteHeader(response.status)
+
+	_, _ = w.Write(response.body)
+}
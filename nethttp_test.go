@@ -0,0 +1,354 @@
+package probes
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewHTTPServer(t *testing.T) {
+	t.Parallel()
+
+	// Act.
+	server := NewHTTPServer(WithAddress(":8080"))
+
+	// Assert.
+	require.NotNil(t, server)
+
+	assert.Nil(t, server.probes)
+	assert.Equal(t, ":8080", server.config.address)
+	assert.Equal(t, server.mux, server.Handler())
+}
+
+func TestHTTPServer_Probes(t *testing.T) {
+	t.Parallel()
+
+	// Arrange.
+	probes := NewCompositeProbes().
+		RegisterLiveness("deadlock-watchdog", testProbe(Success, nil)).
+		RegisterReadiness("postgres", testProbe(Success, nil)).
+		RegisterReadiness("redis", testProbe(Failure, errDummyProbe))
+
+	server := NewHTTPServer(WithReadinessPath("/readyz"), WithoutStartup()).
+		Probes(probes, WithStatuses(map[Result]int{Failure: http.StatusServiceUnavailable}))
+
+	tests := []struct {
+		name           string
+		method         string
+		target         string
+		accept         string
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:           "Liveness-эндпоинт",
+			method:         http.MethodGet,
+			target:         DefaultLivenessPath,
+			expectedStatus: http.StatusOK,
+			expectedBody:   "",
+		},
+		{
+			name:           "Readiness-эндпоинт",
+			method:         http.MethodGet,
+			target:         "/readyz",
+			expectedStatus: http.StatusServiceUnavailable,
+			expectedBody:   "redis: probes: dummy error",
+		},
+		{
+			name:           "Подробный ответ с исключением проверки",
+			method:         http.MethodGet,
+			target:         "/readyz?verbose&exclude=redis",
+			expectedStatus: http.StatusOK,
+			expectedBody:   "[+]postgres ok\nreadiness check passed\n",
+		},
+		{
+			name:           "Именованная проверка",
+			method:         http.MethodGet,
+			target:         "/readyz/postgres",
+			expectedStatus: http.StatusOK,
+			expectedBody:   "",
+		},
+		{
+			name:           "Неизвестная проверка",
+			method:         http.MethodGet,
+			target:         "/readyz/kafka",
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   `probes: unknown check: "kafka"`,
+		},
+		{
+			name:           "Вложенный путь проверки",
+			method:         http.MethodGet,
+			target:         "/readyz/redis/postgres",
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   "404 page not found\n",
+		},
+		{
+			name:           "Отключённый Startup-эндпоинт",
+			method:         http.MethodGet,
+			target:         DefaultStartupPath,
+			expectedStatus: http.StatusNotFound,
+			expectedBody:   "404 page not found\n",
+		},
+		{
+			name:           "Неподдерживаемый метод",
+			method:         http.MethodPost,
+			target:         DefaultLivenessPath,
+			expectedStatus: http.StatusMethodNotAllowed,
+			expectedBody:   "Method Not Allowed\n",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+
+			// Act.
+			server.Handler().ServeHTTP(recorder, httptest.NewRequest(test.method, test.target, nil))
+
+			// Assert.
+			assert.Equal(t, test.expectedStatus, recorder.Code)
+			assert.Equal(t, test.expectedBody, recorder.Body.String())
+		})
+	}
+}
+
+type testCountingReader struct {
+	read int
+}
+
+func (reader *testCountingReader) Read(p []byte) (int, error) {
+	reader.read += len(p)
+
+	for i := range p {
+		p[i] = ' '
+	}
+
+	return len(p), nil
+}
+
+func TestHTTPServer_Override(t *testing.T) {
+	t.Parallel()
+
+	// Arrange.
+	server := NewHTTPServer().Override(NewOverride(DefaultProbes), "secret")
+
+	tests := []struct {
+		name           string
+		authorization  string
+		expectedStatus int
+		expectedBody   string
+		expectedRead   bool
+	}{
+		{
+			name:           "Без токена тело не читается",
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody:   "Unauthorized",
+			expectedRead:   false,
+		},
+		{
+			name:           "Тело превышает ограничение",
+			authorization:  "Bearer secret",
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   ErrOverrideTooLarge.Error(),
+			expectedRead:   true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			body := &testCountingReader{}
+
+			request := httptest.NewRequest(http.MethodPut, DefaultOverridePath, body)
+			request.Header.Set("Authorization", test.authorization)
+
+			recorder := httptest.NewRecorder()
+
+			// Act.
+			server.Handler().ServeHTTP(recorder, request)
+
+			// Assert.
+			assert.Equal(t, test.expectedStatus, recorder.Code)
+			assert.Equal(t, test.expectedBody, recorder.Body.String())
+
+			if test.expectedRead {
+				assert.LessOrEqual(t, body.read, 2*overrideBodyLimit)
+			} else {
+				assert.Zero(t, body.read)
+			}
+		})
+	}
+}
+
+func TestHTTPServer_Serve(t *testing.T) {
+	t.Parallel()
+
+	// Arrange.
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	server := NewHTTPServer().Probes(DefaultProbes)
+
+	done := make(chan error, 1)
+
+	// Act.
+	go func() {
+		done <- server.serve(listener)
+	}()
+
+	// Assert.
+	for _, path := range []string{DefaultLivenessPath, DefaultReadinessPath, DefaultStartupPath} {
+		response, err := http.Get("http://" + listener.Addr().String() + path)
+		require.NoError(t, err)
+
+		_ = response.Body.Close()
+
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+	}
+
+	require.NoError(t, server.Shutdown(context.Background()))
+	assert.NoError(t, <-done)
+}
+
+func TestHTTPServer_StartUnix(t *testing.T) {
+	t.Parallel()
+
+	// Arrange.
+	socket := filepath.Join(t.TempDir(), "probes.sock")
+
+	server := NewHTTPServer().Probes(DefaultProbes)
+
+	done := make(chan error, 1)
+
+	// Act.
+	go func() {
+		done <- server.StartUnix(socket, 0o600)
+	}()
+
+	// Assert.
+	client := testUnixClient(socket)
+
+	assert.Eventually(t, func() bool {
+		response, err := client.Get("http://unix" + DefaultReadinessPath)
+		if err != nil {
+			return false
+		}
+
+		_ = response.Body.Close()
+
+		return response.StatusCode == http.StatusOK
+	}, time.Second, 10*time.Millisecond)
+
+	require.NoError(t, server.Shutdown(context.Background()))
+	assert.NoError(t, <-done)
+}
+
+func testUnixClient(socket string) *http.Client {
+	return &http.Client{
+		Transport: &http.Transport{
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				var dialer net.Dialer
+
+				return dialer.DialContext(ctx, "unix", socket)
+			},
+		},
+	}
+}
+
+func TestHTTPReadiness_ServeHTTP(t *testing.T) {
+	t.Parallel()
+
+	// Arrange.
+	tests := []struct {
+		name                string
+		handler             HTTPReadiness
+		accept              string
+		expectedStatus      int
+		expectedContentType string
+		expectedBody        string
+	}{
+		{
+			name:                `Обработчик ответил "Success"`,
+			handler:             NewHTTPReadiness(testSuccessReadiness{}),
+			expectedStatus:      http.StatusOK,
+			expectedContentType: "",
+			expectedBody:        "",
+		},
+		{
+			name:                `Обработчик ответил "Warning" с ошибкой`,
+			handler:             NewHTTPReadiness(testWarningReadinessWithError{}),
+			expectedStatus:      http.StatusOK,
+			expectedContentType: TextContentType,
+			expectedBody:        dummyFiberError.Error(),
+		},
+		{
+			name:                `Обработчик ответил "Failure" с ошибкой`,
+			handler:             NewHTTPReadiness(testFailureReadinessWithError{}),
+			expectedStatus:      http.StatusInternalServerError,
+			expectedContentType: TextContentType,
+			expectedBody:        dummyFiberError.Error(),
+		},
+		{
+			name:                "Формат application/health+json",
+			handler:             NewHTTPReadiness(testFailureReadinessErrorless{}, WithRenderers(HealthRenderer{})),
+			accept:              HealthContentType,
+			expectedStatus:      http.StatusInternalServerError,
+			expectedContentType: HealthContentType,
+			expectedBody:        `{"status":"fail"}`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+
+			request := httptest.NewRequest(http.MethodGet, DefaultReadinessPath, nil)
+			request.Header.Set("Accept", test.accept)
+
+			// Act.
+			test.handler.ServeHTTP(recorder, request)
+
+			// Assert.
+			body, err := io.ReadAll(recorder.Body)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expectedStatus, recorder.Code)
+			assert.Equal(t, test.expectedContentType, recorder.Header().Get("Content-Type"))
+			assert.Equal(t, test.expectedBody, string(body))
+		})
+	}
+}
+
+func TestNewHTTPLiveness(t *testing.T) {
+	t.Parallel()
+
+	// Act.
+	actualLiveness := NewHTTPLiveness(DefaultLiveness)
+
+	// Assert.
+	assert.Equal(t, DefaultLiveness, actualLiveness.probe)
+}
+
+func TestNewHTTPReadiness(t *testing.T) {
+	t.Parallel()
+
+	// Act.
+	actualReadiness := NewHTTPReadiness(DefaultReadiness)
+
+	// Assert.
+	assert.Equal(t, DefaultReadiness, actualReadiness.probe)
+}
+
+func TestNewHTTPStartup(t *testing.T) {
+	t.Parallel()
+
+	// Act.
+	actualStartup := NewHTTPStartup(DefaultStartup)
+
+	// Assert.
+	assert.Equal(t, DefaultStartup, actualStartup.probe)
+}
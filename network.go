@@ -0,0 +1,272 @@
+package probes
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+)
+
+const (
+	// DefaultTCPTimeout содержит время по умолчанию,
+	// отведённое TCPChecker на подключение, совпадающее
+	// с timeoutSeconds пробы Kubernetes по умолчанию.
+	DefaultTCPTimeout = time.Second
+
+	// DefaultDNSTimeout содержит время по умолчанию,
+	// отведённое DNSChecker на разрешение имени.
+	DefaultDNSTimeout = time.Second
+)
+
+// ErrNoAddresses указывает, что DNS-имя не разрешилось
+// ни в один адрес.
+var ErrNoAddresses = errors.New("probes: no addresses resolved")
+
+// TCPOption настраивает TCPChecker.
+type TCPOption func(*TCPChecker)
+
+// WithTCPDialer задаёт net.Dialer проверки, например для
+// привязки к локальному адресу.
+func WithTCPDialer(dialer *net.Dialer) TCPOption {
+	return func(checker *TCPChecker) {
+		checker.dialer = dialer
+	}
+}
+
+// WithTCPNetwork задаёт сеть подключения: tcp, tcp4
+// или tcp6.
+//
+// По умолчанию используется tcp.
+func WithTCPNetwork(network string) TCPOption {
+	return func(checker *TCPChecker) {
+		checker.network = network
+	}
+}
+
+// WithTCPTimeout задаёт время, отведённое на
+// подключение.
+//
+// По умолчанию используется DefaultTCPTimeout.
+func WithTCPTimeout(timeout time.Duration) TCPOption {
+	return func(checker *TCPChecker) {
+		checker.timeout = timeout
+	}
+}
+
+// TCPChecker реализует Liveness-, Readiness- и
+// Startup-пробы доступности TCP-адреса, например брокера
+// сообщений без собственного API проверки состояния.
+//
+// Проба устанавливает соединение и сразу его закрывает.
+// Ошибка подключения возвращается как Failure вместе со
+// временем попытки, а время подключения передаётся в
+// Report.Details.
+//
+// Для инициализации необходимо использовать метод
+// NewTCPChecker.
+type TCPChecker struct {
+	address string
+	network string
+	dialer  *net.Dialer
+	timeout time.Duration
+}
+
+// NewTCPChecker инициализирует пробу доступности
+// TCP-адреса address.
+func NewTCPChecker(address string, options ...TCPOption) *TCPChecker {
+	checker := &TCPChecker{
+		address: address,
+		network: "tcp",
+		dialer:  &net.Dialer{},
+		timeout: DefaultTCPTimeout,
+	}
+
+	for _, option := range options {
+		option(checker)
+	}
+
+	return checker
+}
+
+func (checker *TCPChecker) Liveness(ctx context.Context) (Result, error) {
+	return checker.report(ctx).Unwrap()
+}
+
+func (checker *TCPChecker) Readiness(ctx context.Context) (Result, error) {
+	return checker.report(ctx).Unwrap()
+}
+
+func (checker *TCPChecker) Startup(ctx context.Context) (Result, error) {
+	return checker.report(ctx).Unwrap()
+}
+
+// LivenessReport возвращает отчёт Liveness-пробы со
+// временем подключения.
+func (checker *TCPChecker) LivenessReport(ctx context.Context) Report {
+	return checker.report(ctx)
+}
+
+// ReadinessReport возвращает отчёт Readiness-пробы.
+//
+//	Смотри LivenessReport
+func (checker *TCPChecker) ReadinessReport(ctx context.Context) Report {
+	return checker.report(ctx)
+}
+
+// StartupReport возвращает отчёт Startup-пробы.
+//
+//	Смотри LivenessReport
+func (checker *TCPChecker) StartupReport(ctx context.Context) Report {
+	return checker.report(ctx)
+}
+
+func (checker *TCPChecker) report(ctx context.Context) Report {
+	report := Report{Result: Success, Component: Component{ID: checker.address, Type: "component"}}
+
+	if checker.timeout > 0 {
+		var cancel context.CancelFunc
+
+		ctx, cancel = context.WithTimeout(ctx, checker.timeout)
+		defer cancel()
+	}
+
+	started := time.Now()
+
+	conn, err := checker.dialer.DialContext(ctx, checker.network, checker.address)
+
+	latency := time.Since(started)
+
+	report.Details = map[string]interface{}{"latency_ms": milliseconds(latency)}
+
+	if err != nil {
+		report.Result, report.Err = Failure, fmt.Errorf("%w (after %s)", err, latency.Truncate(time.Microsecond))
+
+		return report
+	}
+
+	report.Details["remote_address"] = conn.RemoteAddr().String()
+
+	_ = conn.Close()
+
+	return report
+}
+
+// DNSOption настраивает DNSChecker.
+type DNSOption func(*DNSChecker)
+
+// WithDNSResolver задаёт net.Resolver проверки, например
+// для обращения к определённому DNS-серверу.
+//
+// По умолчанию используется net.DefaultResolver.
+func WithDNSResolver(resolver *net.Resolver) DNSOption {
+	return func(checker *DNSChecker) {
+		checker.resolver = resolver
+	}
+}
+
+// WithDNSTimeout задаёт время, отведённое на разрешение
+// имени.
+//
+// По умолчанию используется DefaultDNSTimeout.
+func WithDNSTimeout(timeout time.Duration) DNSOption {
+	return func(checker *DNSChecker) {
+		checker.timeout = timeout
+	}
+}
+
+// DNSChecker реализует Liveness-, Readiness- и
+// Startup-пробы разрешения DNS-имени.
+//
+// Ошибка разрешения или пустой список адресов
+// (ErrNoAddresses) возвращаются как Failure вместе со
+// временем попытки, а время разрешения и адреса
+// передаются в Report.Details.
+//
+// Для инициализации необходимо использовать метод
+// NewDNSChecker.
+type DNSChecker struct {
+	host     string
+	resolver *net.Resolver
+	timeout  time.Duration
+}
+
+// NewDNSChecker инициализирует пробу разрешения
+// DNS-имени host.
+func NewDNSChecker(host string, options ...DNSOption) *DNSChecker {
+	checker := &DNSChecker{
+		host:     host,
+		resolver: net.DefaultResolver,
+		timeout:  DefaultDNSTimeout,
+	}
+
+	for _, option := range options {
+		option(checker)
+	}
+
+	return checker
+}
+
+func (checker *DNSChecker) Liveness(ctx context.Context) (Result, error) {
+	return checker.report(ctx).Unwrap()
+}
+
+func (checker *DNSChecker) Readiness(ctx context.Context) (Result, error) {
+	return checker.report(ctx).Unwrap()
+}
+
+func (checker *DNSChecker) Startup(ctx context.Context) (Result, error) {
+	return checker.report(ctx).Unwrap()
+}
+
+// LivenessReport возвращает отчёт Liveness-пробы со
+// временем разрешения и адресами.
+func (checker *DNSChecker) LivenessReport(ctx context.Context) Report {
+	return checker.report(ctx)
+}
+
+// ReadinessReport возвращает отчёт Readiness-пробы.
+//
+//	Смотри LivenessReport
+func (checker *DNSChecker) ReadinessReport(ctx context.Context) Report {
+	return checker.report(ctx)
+}
+
+// StartupReport возвращает отчёт Startup-пробы.
+//
+//	Смотри LivenessReport
+func (checker *DNSChecker) StartupReport(ctx context.Context) Report {
+	return checker.report(ctx)
+}
+
+func (checker *DNSChecker) report(ctx context.Context) Report {
+	report := Report{Result: Success, Component: Component{ID: checker.host, Type: "component"}}
+
+	if checker.timeout > 0 {
+		var cancel context.CancelFunc
+
+		ctx, cancel = context.WithTimeout(ctx, checker.timeout)
+		defer cancel()
+	}
+
+	started := time.Now()
+
+	addresses, err := checker.resolver.LookupHost(ctx, checker.host)
+	if err == nil && len(addresses) == 0 {
+		err = ErrNoAddresses
+	}
+
+	latency := time.Since(started)
+
+	report.Details = map[string]interface{}{"latency_ms": milliseconds(latency)}
+
+	if err != nil {
+		report.Result, report.Err = Failure, fmt.Errorf("%w (after %s)", err, latency.Truncate(time.Microsecond))
+
+		return report
+	}
+
+	report.Details["addresses"] = addresses
+
+	return report
+}
@@ -0,0 +1,159 @@
+package probes
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTCPChecker_Liveness(t *testing.T) {
+	t.Parallel()
+
+	// Arrange.
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	closed, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	require.NoError(t, closed.Close())
+
+	defer listener.Close()
+
+	tests := []struct {
+		name           string
+		address        string
+		expectedResult Result
+	}{
+		{
+			name:           "Адрес доступен",
+			address:        listener.Addr().String(),
+			expectedResult: Success,
+		},
+		{
+			name:           "Адрес недоступен",
+			address:        closed.Addr().String(),
+			expectedResult: Failure,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			probe := NewTCPChecker(test.address, WithTCPNetwork("tcp4"))
+
+			// Act.
+			result, err := probe.Liveness(context.Background())
+
+			// Assert.
+			assert.Equal(t, test.expectedResult, result)
+
+			if test.expectedResult.IsSuccess() {
+				assert.NoError(t, err)
+			} else {
+				var opErr *net.OpError
+
+				assert.True(t, errors.As(err, &opErr))
+			}
+
+			report := probe.ReadinessReport(context.Background())
+
+			assert.Equal(t, test.address, report.Component.ID)
+			assert.Contains(t, report.Details, "latency_ms")
+		})
+	}
+}
+
+func TestDNSChecker_StartupReport(t *testing.T) {
+	t.Parallel()
+
+	// Arrange.
+	resolver := &net.Resolver{
+		PreferGo: true,
+		Dial: func(context.Context, string, string) (net.Conn, error) {
+			return nil, errDummyProbe
+		},
+	}
+
+	tests := []struct {
+		name           string
+		host           string
+		expectedResult Result
+	}{
+		{
+			name:           "Имя разрешается",
+			host:           "localhost",
+			expectedResult: Success,
+		},
+		{
+			name:           "Имя не разрешается",
+			host:           "probes.invalid",
+			expectedResult: Failure,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			probe := NewDNSChecker(test.host, WithDNSResolver(resolver))
+
+			// Act.
+			report := probe.StartupReport(context.Background())
+
+			// Assert.
+			assert.Equal(t, test.expectedResult, report.Result)
+			assert.Contains(t, report.Details, "latency_ms")
+
+			if test.expectedResult.IsSuccess() {
+				assert.NoError(t, report.Err)
+				assert.NotEmpty(t, report.Details["addresses"])
+			} else {
+				assert.ErrorContains(t, report.Err, "(after ")
+			}
+		})
+	}
+}
+
+func TestTCPChecker_Composite(t *testing.T) {
+	t.Parallel()
+
+	// Arrange.
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	defer listener.Close()
+
+	probes := NewCompositeProbes().
+		RegisterReadiness("kafka", NewTCPChecker(listener.Addr().String()))
+
+	// Act.
+	result, err := probes.Readiness(context.Background())
+
+	// Assert.
+	assert.Equal(t, Success, result)
+	assert.NoError(t, err)
+}
+
+func TestDNSChecker_ReadinessReport_Timeout(t *testing.T) {
+	t.Parallel()
+
+	// Arrange.
+	resolver := &net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, _, _ string) (net.Conn, error) {
+			<-ctx.Done()
+
+			return nil, ctx.Err()
+		},
+	}
+
+	probe := NewDNSChecker("billing.internal", WithDNSResolver(resolver), WithDNSTimeout(10*time.Millisecond))
+
+	// Act.
+	report := probe.ReadinessReport(context.Background())
+
+	// Assert.
+	assert.Equal(t, Failure, report.Result)
+	assert.Error(t, report.Err)
+	assert.Less(t, report.Details["latency_ms"], float64(DefaultDNSTimeout.Milliseconds()))
+}
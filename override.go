@@ -0,0 +1,351 @@
+package probes
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultOverridePath содержит путь по умолчанию
+// административного эндпоинта Override.
+//
+//	Смотри FiberServer.Override
+const DefaultOverridePath = "/override"
+
+// overrideBodyLimit ограничивает размер тела запроса
+// административного эндпоинта Override.
+const overrideBodyLimit = 4 << 10
+
+var (
+	// ErrOverridden указывает, что результат
+	// Readiness-пробы задан оператором через Override.
+	ErrOverridden = errors.New("probes: readiness overridden")
+
+	// ErrOverrideTooLarge указывает, что тело запроса к
+	// административному эндпоинту Override превышает
+	// допустимый размер.
+	ErrOverrideTooLarge = errors.New("probes: override body too large")
+)
+
+// Override позволяет оператору принудительно задать
+// результат Readiness-пробы, например чтобы вывести под
+// из балансировки для профилирования, не перезапуская
+// его.
+//
+// Liveness и Startup всегда возвращают результат
+// исходных проб. Пока переопределение активно, Readiness
+// возвращает заданный результат с ошибкой ErrOverridden,
+// содержащей причину. По истечении TTL переопределение
+// снимается автоматически, и Readiness снова возвращает
+// результат исходной пробы.
+//
+// Расширенные отчёты и именованные проверки исходных
+// проб, например CompositeProbes, передаются
+// HTTP-обработчикам, а активное переопределение
+// применяется и к ним.
+//
+// Для инициализации необходимо использовать метод
+// NewOverride.
+type Override struct {
+	probes Probes
+	now    func() time.Time
+
+	mu     sync.RWMutex
+	state  OverrideState
+	active bool
+}
+
+// OverrideState содержит активное переопределение
+// Readiness-пробы.
+type OverrideState struct {
+	// Result содержит принудительный результат пробы.
+	Result Result
+
+	// Reason содержит причину, указанную оператором.
+	Reason string
+
+	// Expires содержит время снятия переопределения.
+	// Нулевое значение означает, что переопределение
+	// действует до вызова Override.Clear.
+	Expires time.Time
+}
+
+// NewOverride инициализирует Override поверх проб
+// Kubernetes.
+func NewOverride(probes Probes) *Override {
+	return &Override{probes: probes, now: time.Now}
+}
+
+// Set принудительно задаёт результат Readiness-пробы с
+// причиной reason на время ttl.
+//
+// Если ttl не больше нуля, переопределение действует до
+// вызова Override.Clear.
+func (override *Override) Set(result Result, reason string, ttl time.Duration) error {
+	if err := result.Validate(); err != nil {
+		return err
+	}
+
+	state := OverrideState{Result: result, Reason: reason}
+	if ttl > 0 {
+		state.Expires = override.now().Add(ttl)
+	}
+
+	override.mu.Lock()
+	defer override.mu.Unlock()
+
+	override.state, override.active = state, true
+
+	return nil
+}
+
+// Clear снимает переопределение Readiness-пробы.
+func (override *Override) Clear() {
+	override.mu.Lock()
+	defer override.mu.Unlock()
+
+	override.state, override.active = OverrideState{}, false
+}
+
+// State возвращает активное переопределение и true либо
+// false, если переопределение не задано или истекло.
+func (override *Override) State() (OverrideState, bool) {
+	override.mu.RLock()
+	defer override.mu.RUnlock()
+
+	if !override.active {
+		return OverrideState{}, false
+	}
+
+	if !override.state.Expires.IsZero() && !override.now().Before(override.state.Expires) {
+		return OverrideState{}, false
+	}
+
+	return override.state, true
+}
+
+func (override *Override) Liveness(ctx context.Context) (Result, error) {
+	return override.probes.Liveness(ctx)
+}
+
+func (override *Override) Readiness(ctx context.Context) (Result, error) {
+	if report, active := override.overridden(); active {
+		return report.Unwrap()
+	}
+
+	return override.probes.Readiness(ctx)
+}
+
+func (override *Override) Startup(ctx context.Context) (Result, error) {
+	return override.probes.Startup(ctx)
+}
+
+// LivenessReport возвращает отчёт Liveness-пробы
+// исходных проб, включая результаты их проверок.
+func (override *Override) LivenessReport(ctx context.Context) Report {
+	return livenessReporter(override.probes)(ctx)
+}
+
+// ReadinessReport возвращает отчёт Readiness-пробы
+// исходных проб, а при активном переопределении –
+// заданный результат без выполнения проверок.
+func (override *Override) ReadinessReport(ctx context.Context) Report {
+	if report, active := override.overridden(); active {
+		return report
+	}
+
+	return readinessReporter(override.probes)(ctx)
+}
+
+// StartupReport возвращает отчёт Startup-пробы
+// исходных проб, включая результаты их проверок.
+func (override *Override) StartupReport(ctx context.Context) Report {
+	return startupReporter(override.probes)(ctx)
+}
+
+// LivenessCheck выполняет именованную проверку
+// Liveness-пробы исходных проб, если они её
+// поддерживают.
+//
+//	Смотри CompositeProbes.LivenessCheck
+func (override *Override) LivenessCheck(ctx context.Context, name string) (CheckResult, bool) {
+	return livenessCheckRunner(override.probes)(ctx, name)
+}
+
+// ReadinessCheck выполняет именованную проверку
+// Readiness-пробы исходных проб. При активном
+// переопределении результатом проверки становится
+// заданный результат.
+//
+//	Смотри CompositeProbes.ReadinessCheck
+func (override *Override) ReadinessCheck(ctx context.Context, name string) (CheckResult, bool) {
+	check, found := readinessCheckRunner(override.probes)(ctx, name)
+	if !found {
+		return check, false
+	}
+
+	if report, active := override.overridden(); active {
+		check.Result, check.Err = report.Unwrap()
+	}
+
+	return check, true
+}
+
+// StartupCheck выполняет именованную проверку
+// Startup-пробы исходных проб, если они её
+// поддерживают.
+//
+//	Смотри CompositeProbes.StartupCheck
+func (override *Override) StartupCheck(ctx context.Context, name string) (CheckResult, bool) {
+	return startupCheckRunner(override.probes)(ctx, name)
+}
+
+// overridden возвращает отчёт активного
+// переопределения и true либо false, если
+// переопределение не задано или истекло.
+func (override *Override) overridden() (Report, bool) {
+	state, active := override.State()
+	if !active {
+		return Report{}, false
+	}
+
+	if state.Reason == "" {
+		return Report{Result: state.Result, Err: ErrOverridden}, true
+	}
+
+	return Report{Result: state.Result, Err: fmt.Errorf("%w: %s", ErrOverridden, state.Reason)}, true
+}
+
+type overrideRequest struct {
+	Result string `json:"result"`
+	Reason string `json:"reason"`
+	TTL    string `json:"ttl"`
+}
+
+type overrideResponse struct {
+	Active  bool   `json:"active"`
+	Result  string `json:"result,omitempty"`
+	Reason  string `json:"reason,omitempty"`
+	Expires string `json:"expires,omitempty"`
+}
+
+// serve обрабатывает запрос к административному
+// эндпоинту независимо от HTTP-фреймворка.
+//
+//	Смотри FiberServer.Override
+func (override *Override) serve(token, method, authorization string, body []byte) response {
+	if !authorized(token, authorization) {
+		return unauthorized()
+	}
+
+	if len(body) > overrideBodyLimit {
+		return overrideTooLarge()
+	}
+
+	switch method {
+	case http.MethodGet:
+	case http.MethodPut:
+		if err := override.set(body); err != nil {
+			return response{
+				status:      http.StatusBadRequest,
+				contentType: TextContentType,
+				body:        []byte(err.Error()),
+			}
+		}
+	case http.MethodDelete:
+		override.Clear()
+	default:
+		return response{
+			status:      http.StatusMethodNotAllowed,
+			contentType: TextContentType,
+			headers:     map[string]string{"Allow": "GET, PUT, DELETE"},
+			body:        []byte(http.StatusText(http.StatusMethodNotAllowed)),
+		}
+	}
+
+	var state overrideResponse
+
+	if current, active := override.State(); active {
+		state = overrideResponse{Active: true, Result: current.Result.String(), Reason: current.Reason}
+		if !current.Expires.IsZero() {
+			state.Expires = current.Expires.UTC().Format(time.RFC3339Nano)
+		}
+	}
+
+	encoded, err := json.Marshal(state)
+	if err != nil {
+		return response{
+			status:      http.StatusInternalServerError,
+			contentType: TextContentType,
+			body:        []byte(err.Error()),
+		}
+	}
+
+	return response{status: http.StatusOK, contentType: JSONContentType, body: encoded}
+}
+
+func unauthorized() response {
+	return response{
+		status:      http.StatusUnauthorized,
+		contentType: TextContentType,
+		headers:     map[string]string{"WWW-Authenticate": "Bearer"},
+		body:        []byte(http.StatusText(http.StatusUnauthorized)),
+	}
+}
+
+func overrideTooLarge() response {
+	return response{
+		status:      http.StatusBadRequest,
+		contentType: TextContentType,
+		body:        []byte(ErrOverrideTooLarge.Error()),
+	}
+}
+
+func (override *Override) set(body []byte) error {
+	var request overrideRequest
+	if err := json.Unmarshal(body, &request); err != nil {
+		return fmt.Errorf("probes: invalid override: %w", err)
+	}
+
+	result := Failure
+
+	if request.Result != "" {
+		if err := result.UnmarshalText([]byte(request.Result)); err != nil {
+			return err
+		}
+	}
+
+	var ttl time.Duration
+
+	if request.TTL != "" {
+		parsed, err := time.ParseDuration(request.TTL)
+		if err != nil {
+			return fmt.Errorf("probes: invalid override ttl: %w", err)
+		}
+
+		ttl = parsed
+	}
+
+	return override.Set(result, request.Reason, ttl)
+}
+
+// authorized сравнивает токен из заголовка Authorization
+// за постоянное время. Пустой token запрещает доступ.
+func authorized(token, authorization string) bool {
+	if token == "" {
+		return false
+	}
+
+	scheme, credentials, found := strings.Cut(authorization, " ")
+	if !found || !strings.EqualFold(scheme, "Bearer") {
+		return false
+	}
+
+	return subtle.ConstantTimeCompare([]byte(credentials), []byte(token)) == 1
+}
@@ -0,0 +1,207 @@
+package probes
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOverride_Readiness(t *testing.T) {
+	t.Parallel()
+
+	// Arrange.
+	now := time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	override := NewOverride(testProbe(Success, nil))
+	override.now = func() time.Time { return now }
+
+	ctx := context.Background()
+
+	// Act.
+	require.NoError(t, override.Set(Failure, "profiling", time.Minute))
+
+	result, err := override.Readiness(ctx)
+
+	// Assert.
+	assert.Equal(t, Failure, result)
+	assert.ErrorIs(t, err, ErrOverridden)
+	assert.EqualError(t, err, "probes: readiness overridden: profiling")
+
+	state, active := override.State()
+	assert.True(t, active)
+	assert.Equal(t, OverrideState{Result: Failure, Reason: "profiling", Expires: now.Add(time.Minute)}, state)
+
+	now = now.Add(time.Minute)
+
+	result, err = override.Readiness(ctx)
+	assert.Equal(t, Success, result)
+	assert.NoError(t, err)
+}
+
+func TestOverride_Clear(t *testing.T) {
+	t.Parallel()
+
+	// Arrange.
+	override := NewOverride(testProbe(Failure, errDummyProbe))
+
+	require.NoError(t, override.Set(Success, "", 0))
+
+	result, err := override.Readiness(context.Background())
+	require.Equal(t, Success, result)
+	require.Equal(t, ErrOverridden, err)
+
+	// Act.
+	override.Clear()
+
+	// Assert.
+	result, err = override.Readiness(context.Background())
+	assert.Equal(t, Failure, result)
+	assert.ErrorIs(t, err, errDummyProbe)
+}
+
+func TestOverride_Handler(t *testing.T) {
+	t.Parallel()
+
+	// Arrange.
+	composite := NewCompositeProbes().
+		RegisterReadiness("postgres", testProbe(Success, nil)).
+		RegisterReadiness("redis", testProbe(Success, nil))
+
+	override := NewOverride(composite)
+
+	server := NewHTTPServer().Probes(override, WithJSON())
+
+	serve := func(target string) (int, string) {
+		recorder := httptest.NewRecorder()
+
+		server.Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, target, nil))
+
+		return recorder.Code, recorder.Body.String()
+	}
+
+	// Act.
+	status, body := serve("/readiness?exclude=redis")
+
+	require.NoError(t, override.Set(Failure, "profiling", 0))
+
+	overriddenStatus, overriddenBody := serve("/readiness/postgres")
+	unknownStatus, _ := serve("/readiness/kafka")
+
+	// Assert.
+	assert.Equal(t, http.StatusOK, status)
+	assert.Contains(t, body, `"checks":[{"name":"postgres","status":"success"`)
+	assert.NotContains(t, body, "redis")
+
+	assert.Equal(t, http.StatusInternalServerError, overriddenStatus)
+	assert.Contains(t, overriddenBody, `"error":"probes: readiness overridden: profiling"`)
+	assert.Equal(t, http.StatusNotFound, unknownStatus)
+}
+
+func TestOverride_Set_Unsupported(t *testing.T) {
+	t.Parallel()
+
+	// Arrange.
+	override := NewOverride(DefaultProbes)
+
+	// Act.
+	err := override.Set(Result(42), "", 0)
+
+	// Assert.
+	assert.ErrorIs(t, err, ErrUnsupportedResult)
+
+	_, active := override.State()
+	assert.False(t, active)
+}
+
+func TestOverride_serve(t *testing.T) {
+	t.Parallel()
+
+	// Arrange.
+	override := NewOverride(DefaultProbes)
+	override.now = func() time.Time { return time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC) }
+
+	tests := []struct {
+		name           string
+		method         string
+		authorization  string
+		body           string
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:           "Без токена",
+			method:         http.MethodGet,
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody:   "Unauthorized",
+		},
+		{
+			name:           "Неверный токен",
+			method:         http.MethodGet,
+			authorization:  "Bearer guess",
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody:   "Unauthorized",
+		},
+		{
+			name:           "Переопределение не задано",
+			method:         http.MethodGet,
+			authorization:  "Bearer secret",
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"active":false}`,
+		},
+		{
+			name:           "Неизвестный результат",
+			method:         http.MethodPut,
+			authorization:  "Bearer secret",
+			body:           `{"result":"maybe"}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `probes: unsupported result: "maybe"`,
+		},
+		{
+			name:           "Задать переопределение",
+			method:         http.MethodPut,
+			authorization:  "Bearer secret",
+			body:           `{"reason":"profiling","ttl":"15m"}`,
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"active":true,"result":"failure","reason":"profiling","expires":"2022-06-01T12:15:00Z"}`,
+		},
+		{
+			name:           "Снять переопределение",
+			method:         http.MethodDelete,
+			authorization:  "Bearer secret",
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"active":false}`,
+		},
+		{
+			name:           "Неподдерживаемый метод",
+			method:         http.MethodPost,
+			authorization:  "Bearer secret",
+			expectedStatus: http.StatusMethodNotAllowed,
+			expectedBody:   "Method Not Allowed",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Act.
+			response := override.serve("secret", test.method, test.authorization, []byte(test.body))
+
+			// Assert.
+			assert.Equal(t, test.expectedStatus, response.status)
+			assert.Equal(t, test.expectedBody, string(response.body))
+		})
+	}
+}
+
+func TestOverride_serve_EmptyToken(t *testing.T) {
+	t.Parallel()
+
+	// Act.
+	response := NewOverride(DefaultProbes).serve("", http.MethodGet, "Bearer ", nil)
+
+	// Assert.
+	assert.Equal(t, http.StatusUnauthorized, response.status)
+}
@@ -3,6 +3,8 @@ package probes
 import (
 	"context"
 	"errors"
+	"fmt"
+	"strings"
 )
 
 // Probes содержит контракты для реализации проб
@@ -51,6 +53,28 @@ func NewSuccessProbes() *SuccessProbes {
 	}
 }
 
+// ProbeFunc позволяет использовать обычную функцию
+// в качестве Liveness-, Readiness- или Startup-пробы
+// Kubernetes.
+//
+// В качестве функции удобно использовать метод уже
+// существующей пробы, например:
+//
+//	probes.ProbeFunc(database.Readiness)
+type ProbeFunc func(context.Context) (Result, error)
+
+func (probe ProbeFunc) Liveness(ctx context.Context) (Result, error) {
+	return probe(ctx)
+}
+
+func (probe ProbeFunc) Readiness(ctx context.Context) (Result, error) {
+	return probe(ctx)
+}
+
+func (probe ProbeFunc) Startup(ctx context.Context) (Result, error) {
+	return probe(ctx)
+}
+
 // ErrUnsupportedResult указывает, что используемый
 // результат в ответе эндпоинта не поддерживается.
 //
@@ -61,6 +85,7 @@ var results = map[Result]string{
 	Success: "success",
 	Warning: "warning",
 	Failure: "failure",
+	Unknown: "unknown",
 }
 
 // Result определяет результат, возвращаемый
@@ -71,18 +96,33 @@ var results = map[Result]string{
 //	Success
 //	Warning
 //	Failure
+//	Unknown
+//
+// Result сериализуется в текст и обратно методами
+// MarshalText и UnmarshalText, например в JSON и
+// файлах конфигурации.
 type Result uint8
 
 const (
 	// Success указывает на успешный ответ эндпоинта.
 	Success Result = iota
 
-	// Warning логически означает успешный ответ, но
-	// с дополнительной отладочной информацией.
+	// Warning означает деградацию: эндпоинт работает,
+	// но ответ содержит дополнительную отладочную
+	// информацию о частичном сбое.
+	//
+	// Отдельного результата Degraded нет: деградация
+	// обрабатывается как Warning, в том числе в кодах
+	// HTTP-ответа и статусах gRPC.
 	Warning
 
 	// Failure указывает на ответ с ошибкой от эндпоинта.
 	Failure
+
+	// Unknown указывает, что результат ещё неизвестен:
+	// проверка ни разу не выполнялась или была
+	// пропущена.
+	Unknown
 )
 
 func (r Result) String() string {
@@ -121,6 +161,58 @@ func (r Result) IsFailure() bool {
 	return r.is(Failure)
 }
 
+// IsUnknown возвращает true, если результат
+// равен Unknown.
+func (r Result) IsUnknown() bool {
+	return r.is(Unknown)
+}
+
+// MarshalText возвращает текстовое представление
+// результата, например "success".
+func (r Result) MarshalText() ([]byte, error) {
+	if err := r.Validate(); err != nil {
+		return nil, err
+	}
+
+	return []byte(r.String()), nil
+}
+
+// UnmarshalText разбирает текстовое представление
+// результата без учёта регистра.
+func (r *Result) UnmarshalText(text []byte) error {
+	for result, name := range results {
+		if strings.EqualFold(name, string(text)) {
+			*r = result
+
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%w: %q", ErrUnsupportedResult, text)
+}
+
 func (r Result) is(other Result) bool {
 	return r == other
 }
+
+// Aggregate объединяет несколько результатов в один.
+//
+// Если среди результатов есть Failure или неподдерживаемый
+// результат, то возвращается Failure, если есть Unknown –
+// Unknown, если есть Warning – Warning, иначе – Success.
+func Aggregate(results ...Result) Result {
+	aggregated := Success
+
+	for _, result := range results {
+		switch {
+		case result.Validate() != nil || result.IsFailure():
+			return Failure
+		case result.IsUnknown():
+			aggregated = Unknown
+		case result.IsWarning() && !aggregated.IsUnknown():
+			aggregated = Warning
+		}
+	}
+
+	return aggregated
+}
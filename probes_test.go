@@ -1,11 +1,17 @@
 package probes
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
+var errDummyProbe = errors.New("probes: dummy error")
+
 func TestResult_String(t *testing.T) {
 	t.Parallel()
 
@@ -30,6 +36,11 @@ func TestResult_String(t *testing.T) {
 			result:         Failure,
 			expectedString: "failure",
 		},
+		{
+			name:           `Результат "Unknown"`,
+			result:         Unknown,
+			expectedString: "unknown",
+		},
 		{
 			name:           "Неподдерживаемый результат",
 			result:         100,
@@ -71,6 +82,11 @@ func TestResult_Validate(t *testing.T) {
 			result:      Failure,
 			expectedErr: nil,
 		},
+		{
+			name:        `Результат "Unknown"`,
+			result:      Unknown,
+			expectedErr: nil,
+		},
 		{
 			name:        "Неподдерживаемый результат",
 			result:      100,
@@ -210,3 +226,157 @@ func TestResult_IsFailure(t *testing.T) {
 		})
 	}
 }
+
+func TestResult_IsUnknown(t *testing.T) {
+	t.Parallel()
+
+	// Assert.
+	assert.True(t, Unknown.IsUnknown())
+	assert.False(t, Failure.IsUnknown())
+	assert.False(t, Result(100).IsUnknown())
+}
+
+func TestResult_MarshalText(t *testing.T) {
+	t.Parallel()
+
+	// Arrange.
+	type config struct {
+		Result Result `json:"result"`
+	}
+
+	for _, result := range []Result{Success, Warning, Failure, Unknown} {
+		// Act.
+		encoded, err := json.Marshal(config{Result: result})
+		require.NoError(t, err)
+
+		var decoded config
+
+		err = json.Unmarshal(encoded, &decoded)
+
+		// Assert.
+		require.NoError(t, err)
+
+		assert.Equal(t, `{"result":"`+result.String()+`"}`, string(encoded))
+		assert.Equal(t, result, decoded.Result)
+	}
+
+	_, err := Result(100).MarshalText()
+	assert.ErrorIs(t, err, ErrUnsupportedResult)
+}
+
+func TestResult_UnmarshalText(t *testing.T) {
+	t.Parallel()
+
+	// Arrange.
+	tests := []struct {
+		name           string
+		text           string
+		expectedResult Result
+		expectedErr    error
+	}{
+		{
+			name:           "Результат в нижнем регистре",
+			text:           "warning",
+			expectedResult: Warning,
+			expectedErr:    nil,
+		},
+		{
+			name:           "Результат в верхнем регистре",
+			text:           "UNKNOWN",
+			expectedResult: Unknown,
+			expectedErr:    nil,
+		},
+		{
+			name:           "Неподдерживаемый результат",
+			text:           "maybe",
+			expectedResult: Success,
+			expectedErr:    ErrUnsupportedResult,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var result Result
+
+			// Act.
+			err := result.UnmarshalText([]byte(test.text))
+
+			// Assert.
+			assert.ErrorIs(t, err, test.expectedErr)
+			assert.Equal(t, test.expectedResult, result)
+		})
+	}
+}
+
+func TestProbeFunc(t *testing.T) {
+	t.Parallel()
+
+	// Arrange.
+	probe := ProbeFunc(func(context.Context) (Result, error) {
+		return Warning, errDummyProbe
+	})
+
+	for _, run := range []func(context.Context) (Result, error){probe.Liveness, probe.Readiness, probe.Startup} {
+		// Act.
+		result, err := run(context.Background())
+
+		// Assert.
+		assert.Equal(t, Warning, result)
+		assert.Equal(t, errDummyProbe, err)
+	}
+}
+
+func TestAggregate(t *testing.T) {
+	t.Parallel()
+
+	// Arrange.
+	tests := []struct {
+		name     string
+		results  []Result
+		expected Result
+	}{
+		{
+			name:     "Без результатов",
+			results:  nil,
+			expected: Success,
+		},
+		{
+			name:     `Только "Success"`,
+			results:  []Result{Success, Success},
+			expected: Success,
+		},
+		{
+			name:     `Есть "Warning"`,
+			results:  []Result{Success, Warning, Success},
+			expected: Warning,
+		},
+		{
+			name:     `Есть "Failure"`,
+			results:  []Result{Warning, Failure, Success},
+			expected: Failure,
+		},
+		{
+			name:     `Есть "Unknown"`,
+			results:  []Result{Unknown, Warning, Success},
+			expected: Unknown,
+		},
+		{
+			name:     `"Failure" важнее "Unknown"`,
+			results:  []Result{Unknown, Failure},
+			expected: Failure,
+		},
+		{
+			name:     "Неподдерживаемый результат",
+			results:  []Result{Success, 100},
+			expected: Failure,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Act.
+			actual := Aggregate(test.results...)
+
+			// Assert.
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
@@ -0,0 +1,410 @@
+package probes
+
+import (
+	"encoding/json"
+	"mime"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// TextContentType содержит тип тела ответа TextRenderer.
+	TextContentType = "text/plain; charset=utf-8"
+
+	// JSONContentType содержит тип тела ответа JSONRenderer.
+	JSONContentType = "application/json"
+
+	// HealthContentType содержит тип тела ответа
+	// HealthRenderer.
+	HealthContentType = "application/health+json"
+)
+
+// Renderer формирует тело ответа эндпоинта пробы
+// Kubernetes по отчёту о её выполнении.
+type Renderer interface {
+	// ContentType возвращает значение заголовка
+	// Content-Type для тела ответа.
+	ContentType() string
+
+	// Render формирует тело ответа. Пустое тело
+	// отправляется без заголовка Content-Type.
+	Render(Report) ([]byte, error)
+}
+
+// DefaultRenderer содержит Renderer, используемый
+// HTTP-обработчиками по умолчанию.
+//
+// Renderer по умолчанию – TextRenderer.
+var DefaultRenderer Renderer = TextRenderer{}
+
+// TextRenderer формирует тело ответа из текста ошибки
+// пробы, полученной Report.Unwrap. Если ошибки нет, тело
+// пустое.
+type TextRenderer struct{}
+
+func (renderer TextRenderer) ContentType() string {
+	return TextContentType
+}
+
+func (renderer TextRenderer) Render(report Report) ([]byte, error) {
+	_, err := report.Unwrap()
+	if err == nil {
+		return nil, nil
+	}
+
+	return []byte(err.Error()), nil
+}
+
+// VerboseRenderer формирует подробное тело ответа в
+// формате kube-apiserver: по строке на каждую проверку
+// и итоговая строка.
+//
+//	[+]postgres ok
+//	[+]kafka warning: consumer lag
+//	[-]redis failed: connection refused
+//	readiness check failed
+//
+// HTTP-обработчики используют его, если в запросе
+// передан параметр ?verbose.
+type VerboseRenderer struct{}
+
+func (renderer VerboseRenderer) ContentType() string {
+	return TextContentType
+}
+
+func (renderer VerboseRenderer) Render(report Report) ([]byte, error) {
+	var body strings.Builder
+
+	checks := report.Checks
+	if len(checks) == 0 {
+		result, err := report.Unwrap()
+
+		checks = CheckResults{{Name: report.Probe, Result: result, Err: err}}
+	}
+
+	for _, check := range checks {
+		switch {
+		case check.Result.IsSuccess() && check.Err == nil:
+			body.WriteString("[+]" + check.Name + " ok\n")
+		case check.Result.IsSuccess() || check.Result.IsWarning():
+			body.WriteString("[+]" + check.Name + " warning: " + errorMessage(cause(check.Result, check.Err)) + "\n")
+		case check.Result.IsUnknown():
+			body.WriteString("[-]" + check.Name + " unknown: " + errorMessage(cause(check.Result, check.Err)) + "\n")
+		default:
+			body.WriteString("[-]" + check.Name + " failed: " + errorMessage(cause(check.Result, check.Err)) + "\n")
+		}
+	}
+
+	if report.Result.IsFailure() || report.Result.IsUnknown() || report.Result.Validate() != nil {
+		body.WriteString(report.Probe + " check failed\n")
+	} else {
+		body.WriteString(report.Probe + " check passed\n")
+	}
+
+	return []byte(body.String()), nil
+}
+
+// JSONRenderer формирует тело ответа в формате JSON:
+//
+//	{
+//	  "status": "warning",
+//	  "error": "redis: connection refused",
+//	  "message": "1/2 checks healthy",
+//	  "details": {"connections": 10},
+//	  "component": {"id": "cache", "type": "datastore"},
+//	  "duration_ms": 1.5,
+//	  "timestamp": "2023-01-01T00:00:00Z",
+//	  "checks": [
+//	    {"name": "postgres", "status": "success", "details": {"in_use": 3}, "duration_ms": 0.7},
+//	    {"name": "redis", "status": "warning", "error": "connection refused", "duration_ms": 1.4}
+//	  ]
+//	}
+type JSONRenderer struct{}
+
+func (renderer JSONRenderer) ContentType() string {
+	return JSONContentType
+}
+
+func (renderer JSONRenderer) Render(report Report) ([]byte, error) {
+	_, err := report.Unwrap()
+
+	body := jsonReport{
+		Status:    report.Result.String(),
+		Error:     errorMessage(err),
+		Message:   report.Message,
+		Details:   report.Details,
+		Component: newJSONComponent(report.Component),
+		Duration:  milliseconds(report.Duration),
+		Timestamp: report.Time.UTC().Format(time.RFC3339Nano),
+	}
+
+	for _, check := range report.Checks {
+		body.Checks = append(body.Checks, jsonCheck{
+			Name:      check.Name,
+			Status:    check.Result.String(),
+			Error:     errorMessage(check.Err),
+			Message:   check.Message,
+			Details:   check.Details,
+			Component: newJSONComponent(check.Component),
+			Duration:  milliseconds(check.Duration),
+		})
+	}
+
+	return json.Marshal(body)
+}
+
+func newJSONComponent(component Component) *jsonComponent {
+	if component.ID == "" && component.Type == "" && len(component.Metadata) == 0 {
+		return nil
+	}
+
+	return &jsonComponent{
+		ID:       component.ID,
+		Type:     component.Type,
+		Metadata: component.Metadata,
+	}
+}
+
+type jsonReport struct {
+	Status    string                 `json:"status"`
+	Error     string                 `json:"error,omitempty"`
+	Message   string                 `json:"message,omitempty"`
+	Details   map[string]interface{} `json:"details,omitempty"`
+	Component *jsonComponent         `json:"component,omitempty"`
+	Duration  float64                `json:"duration_ms"`
+	Timestamp string                 `json:"timestamp"`
+	Checks    []jsonCheck            `json:"checks,omitempty"`
+}
+
+type jsonComponent struct {
+	ID       string            `json:"id,omitempty"`
+	Type     string            `json:"type,omitempty"`
+	Metadata map[string]string `json:"metadata,omitempty"`
+}
+
+type jsonCheck struct {
+	Name      string                 `json:"name"`
+	Status    string                 `json:"status"`
+	Error     string                 `json:"error,omitempty"`
+	Message   string                 `json:"message,omitempty"`
+	Details   map[string]interface{} `json:"details,omitempty"`
+	Component *jsonComponent         `json:"component,omitempty"`
+	Duration  float64                `json:"duration_ms"`
+}
+
+var healthStatuses = map[Result]string{
+	Success: "pass",
+	Warning: "warn",
+	Failure: "fail",
+	Unknown: "fail",
+}
+
+// HealthRenderer формирует тело ответа в формате
+// application/health+json (draft-inadarei-api-health-check):
+//
+//	{
+//	  "status": "warn",
+//	  "serviceId": "billing",
+//	  "version": "1",
+//	  "output": "redis: connection refused",
+//	  "checks": {
+//	    "redis:responseTime": [{
+//	      "componentId": "redis",
+//	      "componentType": "datastore",
+//	      "status": "warn",
+//	      "observedValue": 1.4,
+//	      "observedUnit": "ms",
+//	      "time": "2023-01-01T00:00:00Z",
+//	      "output": "connection refused",
+//	      "details": {"in_use": 3}
+//	    }]
+//	  }
+//	}
+//
+// Success соответствует статусу pass, Warning – warn,
+// Failure и Unknown – fail. Идентификатор и тип
+// компонента берутся из CheckResult.Component, если
+// проверка их указала, иначе идентификатором служит
+// имя проверки.
+type HealthRenderer struct {
+	// ServiceID содержит идентификатор сервиса.
+	ServiceID string
+
+	// Version содержит публичную версию API сервиса.
+	Version string
+
+	// ReleaseID содержит версию реализации сервиса.
+	ReleaseID string
+
+	// Description содержит описание сервиса.
+	Description string
+}
+
+func (renderer HealthRenderer) ContentType() string {
+	return HealthContentType
+}
+
+func (renderer HealthRenderer) Render(report Report) ([]byte, error) {
+	body := healthReport{
+		Status:      healthStatus(report.Result),
+		Version:     renderer.Version,
+		ReleaseID:   renderer.ReleaseID,
+		ServiceID:   renderer.ServiceID,
+		Description: renderer.Description,
+	}
+
+	if _, err := report.Unwrap(); err != nil {
+		body.Output = err.Error()
+	}
+
+	if len(report.Checks) > 0 {
+		body.Checks = make(map[string][]healthCheck, len(report.Checks))
+	}
+
+	for _, check := range report.Checks {
+		componentID := check.Component.ID
+		if componentID == "" {
+			componentID = check.Name
+		}
+
+		body.Checks[check.Name+":responseTime"] = []healthCheck{{
+			ComponentID:   componentID,
+			ComponentType: check.Component.Type,
+			Status:        healthStatus(check.Result),
+			ObservedValue: milliseconds(check.Duration),
+			ObservedUnit:  "ms",
+			Time:          report.Time.UTC().Format(time.RFC3339Nano),
+			Output:        errorMessage(check.Err),
+			Details:       check.Details,
+		}}
+	}
+
+	return json.Marshal(body)
+}
+
+type healthReport struct {
+	Status      string                   `json:"status"`
+	Version     string                   `json:"version,omitempty"`
+	ReleaseID   string                   `json:"releaseId,omitempty"`
+	ServiceID   string                   `json:"serviceId,omitempty"`
+	Description string                   `json:"description,omitempty"`
+	Output      string                   `json:"output,omitempty"`
+	Checks      map[string][]healthCheck `json:"checks,omitempty"`
+}
+
+type healthCheck struct {
+	ComponentID   string                 `json:"componentId"`
+	ComponentType string                 `json:"componentType,omitempty"`
+	Status        string                 `json:"status"`
+	ObservedValue float64                `json:"observedValue"`
+	ObservedUnit  string                 `json:"observedUnit"`
+	Time          string                 `json:"time"`
+	Output        string                 `json:"output,omitempty"`
+	Details       map[string]interface{} `json:"details,omitempty"`
+}
+
+func healthStatus(result Result) string {
+	status, found := healthStatuses[result]
+	if found {
+		return status
+	}
+
+	return healthStatuses[Failure]
+}
+
+// negotiate выбирает Renderer по заголовку Accept.
+//
+// Диапазоны типов рассматриваются в порядке убывания
+// параметра q. Диапазон */* и отсутствие подходящих
+// Renderer приводят к выбору fallback.
+func negotiate(accept string, fallback Renderer, renderers []Renderer) Renderer {
+	if accept == "" || len(renderers) == 0 {
+		return fallback
+	}
+
+	candidates := append([]Renderer{fallback}, renderers...)
+
+	for _, mediaRange := range parseAccept(accept) {
+		if mediaRange == "*/*" {
+			return fallback
+		}
+
+		for _, renderer := range candidates {
+			if matchMediaRange(mediaRange, renderer.ContentType()) {
+				return renderer
+			}
+		}
+	}
+
+	return fallback
+}
+
+func parseAccept(accept string) []string {
+	type mediaRange struct {
+		mediaType string
+		quality   float64
+	}
+
+	var ranges []mediaRange
+
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+
+		quality := 1.0
+
+		if q, found := params["q"]; found {
+			quality, err = strconv.ParseFloat(q, 64)
+			if err != nil {
+				continue
+			}
+		}
+
+		if quality <= 0 {
+			continue
+		}
+
+		ranges = append(ranges, mediaRange{mediaType: mediaType, quality: quality})
+	}
+
+	sort.SliceStable(ranges, func(i, j int) bool {
+		return ranges[i].quality > ranges[j].quality
+	})
+
+	mediaTypes := make([]string, 0, len(ranges))
+
+	for _, mediaRange := range ranges {
+		mediaTypes = append(mediaTypes, mediaRange.mediaType)
+	}
+
+	return mediaTypes
+}
+
+func matchMediaRange(mediaRange, contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+
+	if strings.HasSuffix(mediaRange, "/*") {
+		return strings.HasPrefix(mediaType, strings.TrimSuffix(mediaRange, "*"))
+	}
+
+	return mediaRange == mediaType
+}
+
+func errorMessage(err error) string {
+	if err == nil {
+		return ""
+	}
+
+	return err.Error()
+}
+
+func milliseconds(duration time.Duration) float64 {
+	return float64(duration) / float64(time.Millisecond)
+}
@@ -0,0 +1,291 @@
+package probes
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTextRenderer_Render(t *testing.T) {
+	t.Parallel()
+
+	// Arrange.
+	tests := []struct {
+		name         string
+		report       Report
+		expectedBody []byte
+	}{
+		{
+			name:         "Проба без ошибки",
+			report:       Report{Result: Success},
+			expectedBody: nil,
+		},
+		{
+			name:         "Проба с ошибкой",
+			report:       Report{Result: Failure, Err: errDummyProbe},
+			expectedBody: []byte("probes: dummy error"),
+		},
+		{
+			name:         "Проба с сообщением",
+			report:       Report{Result: Warning, Message: "2/3 replicas in sync"},
+			expectedBody: []byte("2/3 replicas in sync"),
+		},
+		{
+			name:         "Успешная проба с сообщением",
+			report:       Report{Result: Success, Message: "3/3 replicas in sync"},
+			expectedBody: nil,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Act.
+			body, err := TextRenderer{}.Render(test.report)
+
+			// Assert.
+			assert.NoError(t, err)
+			assert.Equal(t, test.expectedBody, body)
+		})
+	}
+}
+
+func TestJSONRenderer_Render(t *testing.T) {
+	t.Parallel()
+
+	// Arrange.
+	tests := []struct {
+		name         string
+		report       Report
+		expectedBody string
+	}{
+		{
+			name: "Проба без проверок",
+			report: Report{
+				Result:   Success,
+				Duration: 1500 * time.Microsecond,
+				Time:     time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+			},
+			expectedBody: `{"status":"success","duration_ms":1.5,"timestamp":"2023-01-01T00:00:00Z"}`,
+		},
+		{
+			name: "Проба с проверками",
+			report: Report{
+				Result: Warning,
+				Err:    errDummyProbe,
+				Checks: CheckResults{
+					{Name: "postgres", Result: Success, Duration: time.Millisecond},
+					{Name: "redis", Result: Warning, Err: errDummyProbe, Duration: 2 * time.Millisecond},
+				},
+				Duration: 2 * time.Millisecond,
+				Time:     time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+			},
+			expectedBody: `{"status":"warning","error":"probes: dummy error","duration_ms":2,"timestamp":"2023-01-01T00:00:00Z",` +
+				`"checks":[{"name":"postgres","status":"success","duration_ms":1},` +
+				`{"name":"redis","status":"warning","error":"probes: dummy error","duration_ms":2}]}`,
+		},
+		{
+			name: "Расширенный отчёт",
+			report: Report{
+				Result:    Warning,
+				Message:   "2/3 replicas in sync",
+				Details:   map[string]interface{}{"replicas": 3},
+				Component: Component{ID: "postgres", Type: "datastore"},
+				Duration:  time.Millisecond,
+				Time:      time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+			},
+			expectedBody: `{"status":"warning","error":"2/3 replicas in sync","message":"2/3 replicas in sync",` +
+				`"details":{"replicas":3},` +
+				`"component":{"id":"postgres","type":"datastore"},"duration_ms":1,"timestamp":"2023-01-01T00:00:00Z"}`,
+		},
+		{
+			name: "Проверки с расширенным отчётом",
+			report: Report{
+				Result: Success,
+				Checks: CheckResults{{
+					Name:      "postgres",
+					Result:    Success,
+					Message:   "3 connections in use",
+					Details:   map[string]interface{}{"in_use": 3},
+					Component: Component{Type: "datastore"},
+					Duration:  time.Millisecond,
+				}},
+				Duration: time.Millisecond,
+				Time:     time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+			},
+			expectedBody: `{"status":"success","duration_ms":1,"timestamp":"2023-01-01T00:00:00Z",` +
+				`"checks":[{"name":"postgres","status":"success","message":"3 connections in use",` +
+				`"details":{"in_use":3},"component":{"type":"datastore"},"duration_ms":1}]}`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Act.
+			body, err := JSONRenderer{}.Render(test.report)
+
+			// Assert.
+			assert.NoError(t, err)
+			assert.JSONEq(t, test.expectedBody, string(body))
+		})
+	}
+}
+
+func TestHealthRenderer_Render(t *testing.T) {
+	t.Parallel()
+
+	// Arrange.
+	renderer := HealthRenderer{ServiceID: "billing", Version: "1"}
+
+	tests := []struct {
+		name         string
+		report       Report
+		expectedBody string
+	}{
+		{
+			name:         `Результат "Success"`,
+			report:       Report{Result: Success},
+			expectedBody: `{"status":"pass","serviceId":"billing","version":"1"}`,
+		},
+		{
+			name: `Результат "Warning" с проверками`,
+			report: Report{
+				Result: Warning,
+				Err:    errDummyProbe,
+				Checks: CheckResults{
+					{Name: "redis", Result: Warning, Err: errDummyProbe, Duration: 2 * time.Millisecond},
+				},
+				Time: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+			},
+			expectedBody: `{"status":"warn","serviceId":"billing","version":"1","output":"probes: dummy error",` +
+				`"checks":{"redis:responseTime":[{"componentId":"redis","status":"warn","observedValue":2,` +
+				`"observedUnit":"ms","time":"2023-01-01T00:00:00Z","output":"probes: dummy error"}]}}`,
+		},
+		{
+			name: "Проверка с расширенным отчётом",
+			report: Report{
+				Result: Success,
+				Checks: CheckResults{{
+					Name:      "postgres",
+					Result:    Success,
+					Details:   map[string]interface{}{"in_use": 3},
+					Component: Component{ID: "db-1", Type: "datastore"},
+					Duration:  2 * time.Millisecond,
+				}},
+				Time: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+			},
+			expectedBody: `{"status":"pass","serviceId":"billing","version":"1",` +
+				`"checks":{"postgres:responseTime":[{"componentId":"db-1","componentType":"datastore","status":"pass",` +
+				`"observedValue":2,"observedUnit":"ms","time":"2023-01-01T00:00:00Z","details":{"in_use":3}}]}}`,
+		},
+		{
+			name:         `Результат "Failure"`,
+			report:       Report{Result: Failure},
+			expectedBody: `{"status":"fail","serviceId":"billing","version":"1"}`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Act.
+			body, err := renderer.Render(test.report)
+
+			// Assert.
+			assert.NoError(t, err)
+			assert.JSONEq(t, test.expectedBody, string(body))
+		})
+	}
+}
+
+func TestNegotiate(t *testing.T) {
+	t.Parallel()
+
+	// Arrange.
+	renderers := []Renderer{JSONRenderer{}, HealthRenderer{}}
+
+	tests := []struct {
+		name     string
+		accept   string
+		expected Renderer
+	}{
+		{
+			name:     "Без заголовка Accept",
+			accept:   "",
+			expected: TextRenderer{},
+		},
+		{
+			name:     "Любой тип",
+			accept:   "*/*",
+			expected: TextRenderer{},
+		},
+		{
+			name:     "Точное совпадение",
+			accept:   "application/health+json",
+			expected: HealthRenderer{},
+		},
+		{
+			name:     "Выбор по параметру q",
+			accept:   "application/json;q=0.5, application/health+json;q=0.9, */*;q=0.1",
+			expected: HealthRenderer{},
+		},
+		{
+			name:     "Диапазон типов",
+			accept:   "application/*",
+			expected: JSONRenderer{},
+		},
+		{
+			name:     "Неподдерживаемый тип",
+			accept:   "text/html",
+			expected: TextRenderer{},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Act.
+			actual := negotiate(test.accept, TextRenderer{}, renderers)
+
+			// Assert.
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
+
+func TestVerboseRenderer_Render(t *testing.T) {
+	t.Parallel()
+
+	// Arrange.
+	tests := []struct {
+		name         string
+		report       Report
+		expectedBody string
+	}{
+		{
+			name:         "Проба без проверок",
+			report:       Report{Probe: "liveness", Result: Success},
+			expectedBody: "[+]liveness ok\nliveness check passed\n",
+		},
+		{
+			name: "Проба с проверками",
+			report: Report{
+				Probe:  "readiness",
+				Result: Failure,
+				Checks: CheckResults{
+					{Name: "postgres", Result: Success},
+					{Name: "kafka", Result: Warning, Err: errDummyProbe},
+					{Name: "redis", Result: Failure},
+				},
+			},
+			expectedBody: "[+]postgres ok\n" +
+				"[+]kafka warning: probes: dummy error\n" +
+				"[-]redis failed: failure\n" +
+				"readiness check failed\n",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Act.
+			body, err := VerboseRenderer{}.Render(test.report)
+
+			// Assert.
+			assert.NoError(t, err)
+			assert.Equal(t, test.expectedBody, string(body))
+		})
+	}
+}
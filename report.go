@@ -0,0 +1,412 @@
+package probes
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// Report содержит отчёт о выполнении пробы Kubernetes,
+// по которому эндпоинт формирует ответ.
+type Report struct {
+	// Probe содержит вид пробы: liveness, readiness
+	// или startup.
+	Probe string
+
+	// Result содержит результат пробы.
+	Result Result
+
+	// Err содержит ошибку с отладочной информацией,
+	// которую вернула проба.
+	Err error
+
+	// Message содержит поясняющее сообщение пробы,
+	// не являющееся ошибкой, например
+	// "3/5 replicas in sync".
+	Message string
+
+	// Details содержит произвольные подробности пробы,
+	// например статистику пула соединений.
+	Details map[string]interface{}
+
+	// Component содержит описание проверяемого
+	// компонента.
+	Component Component
+
+	// Checks содержит результаты именованных проверок,
+	// если проба состоит из них.
+	//
+	//	Смотри CompositeProbes
+	Checks CheckResults
+
+	// Duration содержит время выполнения пробы.
+	Duration time.Duration
+
+	// Time содержит время начала выполнения пробы.
+	Time time.Time
+}
+
+// Unwrap возвращает результат и ошибку отчёта в виде,
+// который ожидают контракты Liveness, Readiness и
+// Startup.
+//
+// Если результат отличен от Success, ошибка
+// отсутствует, а сообщение задано, ошибка содержит
+// сообщение. Сообщение успешной пробы остаётся
+// информационным и ошибкой не считается.
+//
+// Все Renderer получают ошибку отчёта этим методом.
+func (report Report) Unwrap() (Result, error) {
+	if report.Err == nil && report.Message != "" && !report.Result.IsSuccess() {
+		return report.Result, errors.New(report.Message)
+	}
+
+	return report.Result, report.Err
+}
+
+// Component содержит описание компонента, который
+// проверяет проба.
+type Component struct {
+	// ID содержит идентификатор компонента,
+	// например postgres.
+	ID string
+
+	// Type содержит тип компонента, например
+	// datastore или system.
+	Type string
+
+	// Metadata содержит произвольные сведения о
+	// компоненте, например версию или адрес.
+	Metadata map[string]string
+}
+
+// LivenessReporter оборачивает метод LivenessReport,
+// который возвращает расширенный отчёт Liveness-пробы.
+//
+// HTTP-обработчики используют его вместо метода
+// Liveness, если проба его реализует.
+//
+//	Смотри ReportFunc
+type LivenessReporter interface {
+	LivenessReport(context.Context) Report
+}
+
+// ReadinessReporter оборачивает метод ReadinessReport,
+// который возвращает расширенный отчёт Readiness-пробы.
+//
+//	Смотри LivenessReporter
+type ReadinessReporter interface {
+	ReadinessReport(context.Context) Report
+}
+
+// StartupReporter оборачивает метод StartupReport,
+// который возвращает расширенный отчёт Startup-пробы.
+//
+//	Смотри LivenessReporter
+type StartupReporter interface {
+	StartupReport(context.Context) Report
+}
+
+// ReportFunc позволяет использовать функцию,
+// возвращающую расширенный отчёт, в качестве
+// Liveness-, Readiness- или Startup-пробы Kubernetes.
+//
+// Методы Liveness, Readiness и Startup возвращают
+// Report.Unwrap, поэтому ReportFunc совместима со всеми
+// существующими контрактами, а HTTP-обработчики
+// получают отчёт целиком через LivenessReporter,
+// ReadinessReporter и StartupReporter.
+//
+// Незаполненные поля Probe, Duration и Time отчёта
+// заполняются HTTP-обработчиком.
+type ReportFunc func(context.Context) Report
+
+func (probe ReportFunc) Liveness(ctx context.Context) (Result, error) {
+	return probe(ctx).Unwrap()
+}
+
+func (probe ReportFunc) Readiness(ctx context.Context) (Result, error) {
+	return probe(ctx).Unwrap()
+}
+
+func (probe ReportFunc) Startup(ctx context.Context) (Result, error) {
+	return probe(ctx).Unwrap()
+}
+
+func (probe ReportFunc) LivenessReport(ctx context.Context) Report {
+	return probe(ctx)
+}
+
+func (probe ReportFunc) ReadinessReport(ctx context.Context) Report {
+	return probe(ctx)
+}
+
+func (probe ReportFunc) StartupReport(ctx context.Context) Report {
+	return probe(ctx)
+}
+
+const (
+	livenessProbe  = "liveness"
+	readinessProbe = "readiness"
+	startupProbe   = "startup"
+)
+
+type livenessChecker interface {
+	LivenessChecks(context.Context) CheckResults
+}
+
+type readinessChecker interface {
+	ReadinessChecks(context.Context) CheckResults
+}
+
+type startupChecker interface {
+	StartupChecks(context.Context) CheckResults
+}
+
+type namedLivenessChecker interface {
+	LivenessCheck(context.Context, string) (CheckResult, bool)
+}
+
+type namedReadinessChecker interface {
+	ReadinessCheck(context.Context, string) (CheckResult, bool)
+}
+
+type namedStartupChecker interface {
+	StartupCheck(context.Context, string) (CheckResult, bool)
+}
+
+type reporter func(context.Context) Report
+
+func livenessReporter(probe Liveness) reporter {
+	return func(ctx context.Context) Report {
+		if reporter, ok := probe.(LivenessReporter); ok {
+			return newExtendedReport(ctx, livenessProbe, reporter.LivenessReport)
+		}
+
+		if checker, ok := probe.(livenessChecker); ok {
+			return newChecksReport(ctx, livenessProbe, checker.LivenessChecks)
+		}
+
+		return newReport(ctx, livenessProbe, probe.Liveness)
+	}
+}
+
+func readinessReporter(probe Readiness) reporter {
+	return func(ctx context.Context) Report {
+		if reporter, ok := probe.(ReadinessReporter); ok {
+			return newExtendedReport(ctx, readinessProbe, reporter.ReadinessReport)
+		}
+
+		if checker, ok := probe.(readinessChecker); ok {
+			return newChecksReport(ctx, readinessProbe, checker.ReadinessChecks)
+		}
+
+		return newReport(ctx, readinessProbe, probe.Readiness)
+	}
+}
+
+func startupReporter(probe Startup) reporter {
+	return func(ctx context.Context) Report {
+		if reporter, ok := probe.(StartupReporter); ok {
+			return newExtendedReport(ctx, startupProbe, reporter.StartupReport)
+		}
+
+		if checker, ok := probe.(startupChecker); ok {
+			return newChecksReport(ctx, startupProbe, checker.StartupChecks)
+		}
+
+		return newReport(ctx, startupProbe, probe.Startup)
+	}
+}
+
+type checkReporter func(ctx context.Context, name string) (Report, bool)
+
+func livenessCheckReporter(probe Liveness) checkReporter {
+	return func(ctx context.Context, name string) (Report, bool) {
+		return newCheckReport(ctx, livenessProbe, name, livenessCheckRunner(probe))
+	}
+}
+
+func readinessCheckReporter(probe Readiness) checkReporter {
+	return func(ctx context.Context, name string) (Report, bool) {
+		return newCheckReport(ctx, readinessProbe, name, readinessCheckRunner(probe))
+	}
+}
+
+func startupCheckReporter(probe Startup) checkReporter {
+	return func(ctx context.Context, name string) (Report, bool) {
+		return newCheckReport(ctx, startupProbe, name, startupCheckRunner(probe))
+	}
+}
+
+// checkRunner выполняет именованную проверку пробы.
+// Обёртки над пробами используют его, чтобы передавать
+// запросы именованных проверок обёрнутой пробе.
+type checkRunner func(ctx context.Context, name string) (CheckResult, bool)
+
+func livenessCheckRunner(probe Liveness) checkRunner {
+	if checker, ok := probe.(namedLivenessChecker); ok {
+		return checker.LivenessCheck
+	}
+
+	return unknownCheckRunner
+}
+
+func readinessCheckRunner(probe Readiness) checkRunner {
+	if checker, ok := probe.(namedReadinessChecker); ok {
+		return checker.ReadinessCheck
+	}
+
+	return unknownCheckRunner
+}
+
+func startupCheckRunner(probe Startup) checkRunner {
+	if checker, ok := probe.(namedStartupChecker); ok {
+		return checker.StartupCheck
+	}
+
+	return unknownCheckRunner
+}
+
+func unknownCheckRunner(context.Context, string) (CheckResult, bool) {
+	return CheckResult{}, false
+}
+
+// singleProbe позволяет использовать пробу одного вида
+// в обёртках над Probes: пробы всех видов выполняют её,
+// сохраняя расширенный отчёт и именованные проверки.
+// Вид пробы в отчёте задаёт HTTP-обработчик.
+type singleProbe struct {
+	report reporter
+	check  checkRunner
+}
+
+func livenessOnly(probe Liveness) singleProbe {
+	return singleProbe{report: livenessReporter(probe), check: livenessCheckRunner(probe)}
+}
+
+func readinessOnly(probe Readiness) singleProbe {
+	return singleProbe{report: readinessReporter(probe), check: readinessCheckRunner(probe)}
+}
+
+func startupOnly(probe Startup) singleProbe {
+	return singleProbe{report: startupReporter(probe), check: startupCheckRunner(probe)}
+}
+
+func (probe singleProbe) Liveness(ctx context.Context) (Result, error) {
+	return probe.report(ctx).Unwrap()
+}
+
+func (probe singleProbe) Readiness(ctx context.Context) (Result, error) {
+	return probe.report(ctx).Unwrap()
+}
+
+func (probe singleProbe) Startup(ctx context.Context) (Result, error) {
+	return probe.report(ctx).Unwrap()
+}
+
+func (probe singleProbe) LivenessReport(ctx context.Context) Report {
+	return probe.run(ctx)
+}
+
+func (probe singleProbe) ReadinessReport(ctx context.Context) Report {
+	return probe.run(ctx)
+}
+
+func (probe singleProbe) StartupReport(ctx context.Context) Report {
+	return probe.run(ctx)
+}
+
+func (probe singleProbe) LivenessCheck(ctx context.Context, name string) (CheckResult, bool) {
+	return probe.check(ctx, name)
+}
+
+func (probe singleProbe) ReadinessCheck(ctx context.Context, name string) (CheckResult, bool) {
+	return probe.check(ctx, name)
+}
+
+func (probe singleProbe) StartupCheck(ctx context.Context, name string) (CheckResult, bool) {
+	return probe.check(ctx, name)
+}
+
+func (probe singleProbe) run(ctx context.Context) Report {
+	report := probe.report(ctx)
+	report.Probe = ""
+
+	return report
+}
+
+func newReport(ctx context.Context, kind string, probe ProbeFunc) Report {
+	started := time.Now()
+
+	result, err := probe(ctx)
+
+	return Report{
+		Probe:    kind,
+		Result:   result,
+		Err:      err,
+		Duration: time.Since(started),
+		Time:     started,
+	}
+}
+
+func newExtendedReport(ctx context.Context, kind string, probe func(context.Context) Report) Report {
+	started := time.Now()
+
+	report := probe(ctx)
+
+	if report.Probe == "" {
+		report.Probe = kind
+	}
+
+	if report.Time.IsZero() {
+		report.Time = started
+	}
+
+	if report.Duration == 0 {
+		report.Duration = time.Since(started)
+	}
+
+	return report
+}
+
+func newChecksReport(ctx context.Context, kind string, run func(context.Context) CheckResults) Report {
+	started := time.Now()
+
+	checks := run(ctx)
+	result, err := checks.Unwrap()
+
+	return Report{
+		Probe:    kind,
+		Result:   result,
+		Err:      err,
+		Checks:   checks,
+		Duration: time.Since(started),
+		Time:     started,
+	}
+}
+
+func newCheckReport(
+	ctx context.Context,
+	kind, name string,
+	run func(context.Context, string) (CheckResult, bool),
+) (Report, bool) {
+	started := time.Now()
+
+	check, found := run(ctx, name)
+	if !found {
+		return Report{}, false
+	}
+
+	checks := CheckResults{check}
+	result, err := checks.Unwrap()
+
+	return Report{
+		Probe:    kind,
+		Result:   result,
+		Err:      err,
+		Checks:   checks,
+		Duration: time.Since(started),
+		Time:     started,
+	}, true
+}
@@ -0,0 +1,134 @@
+package probes
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadinessReport(t *testing.T) {
+	t.Parallel()
+
+	// Arrange.
+	tests := []struct {
+		name           string
+		probe          Readiness
+		expectedResult Result
+		expectedErr    error
+		expectedChecks int
+	}{
+		{
+			name:           "Обычная проба",
+			probe:          testProbe(Warning, errDummyProbe),
+			expectedResult: Warning,
+			expectedErr:    errDummyProbe,
+			expectedChecks: 0,
+		},
+		{
+			name: "Составная проба",
+			probe: NewCompositeProbes().
+				RegisterReadiness("postgres", testProbe(Success, nil)).
+				RegisterReadiness("redis", testProbe(Success, nil)),
+			expectedResult: Success,
+			expectedErr:    nil,
+			expectedChecks: 2,
+		},
+		{
+			name: "Расширенный отчёт",
+			probe: ReportFunc(func(context.Context) Report {
+				return Report{Result: Failure, Err: errDummyProbe, Details: map[string]interface{}{"lag": 42}}
+			}),
+			expectedResult: Failure,
+			expectedErr:    errDummyProbe,
+			expectedChecks: 0,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Act.
+			report := readinessReporter(test.probe)(context.Background())
+
+			// Assert.
+			assert.Equal(t, "readiness", report.Probe)
+			assert.Equal(t, test.expectedResult, report.Result)
+			assert.Equal(t, test.expectedErr, report.Err)
+
+			require.Len(t, report.Checks, test.expectedChecks)
+
+			assert.False(t, report.Time.IsZero())
+		})
+	}
+}
+
+func TestReportFunc(t *testing.T) {
+	t.Parallel()
+
+	// Arrange.
+	probe := ReportFunc(func(context.Context) Report {
+		return Report{Result: Warning, Message: "2/3 replicas in sync", Component: Component{ID: "postgres"}}
+	})
+
+	ctx := context.Background()
+
+	// Act.
+	result, err := probe.Readiness(ctx)
+
+	// Assert.
+	assert.Equal(t, Warning, result)
+	assert.EqualError(t, err, "2/3 replicas in sync")
+
+	report := livenessReporter(probe)(ctx)
+
+	assert.Equal(t, "liveness", report.Probe)
+	assert.Equal(t, "postgres", report.Component.ID)
+	assert.False(t, report.Time.IsZero())
+}
+
+func TestReport_Unwrap(t *testing.T) {
+	t.Parallel()
+
+	// Arrange.
+	tests := []struct {
+		name        string
+		report      Report
+		expectedErr string
+	}{
+		{
+			name:        "Без ошибки и сообщения",
+			report:      Report{Result: Success},
+			expectedErr: "",
+		},
+		{
+			name:        "Ошибка важнее сообщения",
+			report:      Report{Result: Failure, Err: errDummyProbe, Message: "ignored"},
+			expectedErr: errDummyProbe.Error(),
+		},
+		{
+			name:        "Только сообщение",
+			report:      Report{Result: Warning, Message: "warming up"},
+			expectedErr: "warming up",
+		},
+		{
+			name:        "Информационное сообщение успешной пробы",
+			report:      Report{Result: Success, Message: "3/3 replicas in sync"},
+			expectedErr: "",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Act.
+			result, err := test.report.Unwrap()
+
+			// Assert.
+			assert.Equal(t, test.report.Result, result)
+
+			if test.expectedErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, test.expectedErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,284 @@
+package probes
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultGCPauseWindow содержит окно по умолчанию, в
+// котором RuntimeChecker ищет наибольшую паузу сборщика
+// мусора.
+const DefaultGCPauseWindow = time.Minute
+
+// cgroupMemoryFiles содержит файлы ограничения памяти
+// контейнера для cgroup v2 и cgroup v1.
+var cgroupMemoryFiles = []string{
+	"/sys/fs/cgroup/memory.max",
+	"/sys/fs/cgroup/memory/memory.limit_in_bytes",
+}
+
+// RuntimeOption настраивает RuntimeChecker.
+type RuntimeOption func(*RuntimeChecker)
+
+// WithGoroutineLimit задаёт число горутин, выше которого
+// проверка возвращает Warning (soft) и Failure (hard).
+//
+// Нулевое значение отключает порог.
+func WithGoroutineLimit(soft, hard int) RuntimeOption {
+	return func(checker *RuntimeChecker) {
+		checker.goroutines = runtimeLimit{soft: float64(soft), hard: float64(hard)}
+	}
+}
+
+// WithHeapLimit задаёт размер кучи в байтах, выше
+// которого проверка возвращает Warning (soft) и
+// Failure (hard).
+//
+// Нулевое значение отключает порог.
+func WithHeapLimit(soft, hard uint64) RuntimeOption {
+	return func(checker *RuntimeChecker) {
+		checker.heap = runtimeLimit{soft: float64(soft), hard: float64(hard)}
+	}
+}
+
+// WithHeapFraction задаёт доли ограничения памяти cgroup
+// контейнера, выше которых размер кучи приводит к
+// Warning (soft) и Failure (hard).
+//
+// Если cgroup не ограничивает память, используется
+// ограничение памяти среды выполнения Go (GOMEMLIMIT).
+// Если не задано ни одно из них, параметр не действует,
+// о чём сообщает Report.Details. Заменяет WithHeapLimit.
+func WithHeapFraction(soft, hard float64) RuntimeOption {
+	return func(checker *RuntimeChecker) {
+		checker.heapFraction = &runtimeLimit{soft: soft, hard: hard}
+	}
+}
+
+// WithGCPauseLimit задаёт длительность паузы сборщика
+// мусора, выше которой проверка возвращает Warning (soft)
+// и Failure (hard).
+//
+// Учитывается наибольшая пауза среди сборок, завершённых
+// в окне WithGCPauseWindow, независимо от частоты
+// проверок. Нулевое значение отключает порог.
+func WithGCPauseLimit(soft, hard time.Duration) RuntimeOption {
+	return func(checker *RuntimeChecker) {
+		checker.gcPause = runtimeLimit{soft: float64(soft), hard: float64(hard)}
+	}
+}
+
+// WithGCPauseWindow задаёт окно, в котором учитываются
+// паузы сборщика мусора. В окне учитываются не более 256
+// последних сборок.
+//
+// По умолчанию, а также вместо нулевого или
+// отрицательного окна, используется DefaultGCPauseWindow.
+func WithGCPauseWindow(window time.Duration) RuntimeOption {
+	return func(checker *RuntimeChecker) {
+		if window <= 0 {
+			window = DefaultGCPauseWindow
+		}
+
+		checker.gcPauseWindow = window
+	}
+}
+
+// RuntimeChecker реализует Liveness-пробу состояния
+// среды выполнения Go: утечек горутин, роста кучи и
+// длительных пауз сборщика мусора, которые исправляются
+// только перезапуском контейнера.
+//
+// Проба возвращает Warning или Failure при превышении
+// соответствующего порога, а ошибка содержит
+// превышенные значения. Все пороги по умолчанию
+// отключены. Показатели среды выполнения передаются в
+// Report.Details.
+//
+// Для инициализации необходимо использовать метод
+// NewRuntimeChecker.
+type RuntimeChecker struct {
+	goroutines   runtimeLimit
+	heap         runtimeLimit
+	heapFraction *runtimeLimit
+	gcPause      runtimeLimit
+
+	gcPauseWindow time.Duration
+	memoryLimit   uint64
+}
+
+// NewRuntimeChecker инициализирует Liveness-пробу
+// состояния среды выполнения Go.
+func NewRuntimeChecker(options ...RuntimeOption) *RuntimeChecker {
+	checker := &RuntimeChecker{
+		gcPauseWindow: DefaultGCPauseWindow,
+		memoryLimit:   cgroupMemoryLimit(cgroupMemoryFiles),
+	}
+
+	if checker.memoryLimit == 0 {
+		checker.memoryLimit = goMemoryLimit()
+	}
+
+	for _, option := range options {
+		option(checker)
+	}
+
+	if checker.heapFraction != nil && checker.memoryLimit > 0 {
+		checker.heap = runtimeLimit{
+			soft: checker.heapFraction.soft * float64(checker.memoryLimit),
+			hard: checker.heapFraction.hard * float64(checker.memoryLimit),
+		}
+	}
+
+	return checker
+}
+
+func (checker *RuntimeChecker) Liveness(ctx context.Context) (Result, error) {
+	return checker.LivenessReport(ctx).Unwrap()
+}
+
+// LivenessReport возвращает отчёт Liveness-пробы с
+// показателями среды выполнения.
+func (checker *RuntimeChecker) LivenessReport(context.Context) Report {
+	var stats runtime.MemStats
+	runtime.ReadMemStats(&stats)
+
+	goroutines := runtime.NumGoroutine()
+	pause := maxPause(&stats, time.Now().Add(-checker.gcPauseWindow))
+
+	report := Report{
+		Component: Component{ID: "go", Type: "system", Metadata: map[string]string{"version": runtime.Version()}},
+		Details: map[string]interface{}{
+			"goroutines":       goroutines,
+			"heap_alloc_bytes": stats.HeapAlloc,
+			"num_gc":           stats.NumGC,
+			"gc_pause_ms":      milliseconds(pause),
+		},
+	}
+
+	if checker.memoryLimit > 0 {
+		report.Details["memory_limit_bytes"] = checker.memoryLimit
+	} else if checker.heapFraction != nil {
+		report.Details["heap_fraction"] = "not applied: memory limit is not set"
+	}
+
+	var (
+		results  []Result
+		messages []string
+	)
+
+	for _, check := range []struct {
+		name  string
+		limit runtimeLimit
+		value float64
+		text  func(float64) string
+	}{
+		{name: "goroutines", limit: checker.goroutines, value: float64(goroutines), text: formatCount},
+		{name: "heap", limit: checker.heap, value: float64(stats.HeapAlloc), text: formatBytes},
+		{name: "gc pause", limit: checker.gcPause, value: float64(pause), text: formatNanoseconds},
+	} {
+		result, err := check.limit.check(check.name, check.value, check.text)
+
+		results = append(results, result)
+
+		if err != nil {
+			messages = append(messages, err.Error())
+		}
+	}
+
+	report.Result = Aggregate(results...)
+
+	if len(messages) > 0 {
+		report.Err = errors.New(strings.Join(messages, "; "))
+	}
+
+	return report
+}
+
+// maxPause возвращает наибольшую паузу среди сборок,
+// завершённых не раньше since.
+func maxPause(stats *runtime.MemStats, since time.Time) time.Duration {
+	completed := stats.NumGC
+	if completed > uint32(len(stats.PauseNs)) {
+		completed = uint32(len(stats.PauseNs))
+	}
+
+	var pause uint64
+
+	for i := uint32(0); i < completed; i++ {
+		index := (stats.NumGC - i + uint32(len(stats.PauseNs)) - 1) % uint32(len(stats.PauseNs))
+
+		// Сборки перебираются от последней к первой.
+		if int64(stats.PauseEnd[index]) < since.UnixNano() {
+			break
+		}
+
+		if stats.PauseNs[index] > pause {
+			pause = stats.PauseNs[index]
+		}
+	}
+
+	return time.Duration(pause)
+}
+
+type runtimeLimit struct {
+	soft float64
+	hard float64
+}
+
+func (limit runtimeLimit) check(name string, value float64, text func(float64) string) (Result, error) {
+	switch {
+	case limit.hard > 0 && value > limit.hard:
+		return Failure, fmt.Errorf("%s %s above %s", name, text(value), text(limit.hard))
+	case limit.soft > 0 && value > limit.soft:
+		return Warning, fmt.Errorf("%s %s above %s", name, text(value), text(limit.soft))
+	default:
+		return Success, nil
+	}
+}
+
+// cgroupMemoryLimit возвращает ограничение памяти
+// контейнера из первого доступного файла files либо 0,
+// если ограничение не задано.
+func cgroupMemoryLimit(files []string) uint64 {
+	for _, file := range files {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			continue
+		}
+
+		limit, err := strconv.ParseUint(strings.TrimSpace(string(content)), 10, 64)
+		if err != nil {
+			// cgroup v2 обозначает отсутствие ограничения как "max".
+			return 0
+		}
+
+		// cgroup v1 обозначает отсутствие ограничения
+		// значением, близким к максимальному int64.
+		if limit >= 1<<62 {
+			return 0
+		}
+
+		return limit
+	}
+
+	return 0
+}
+
+func formatCount(value float64) string {
+	return strconv.FormatFloat(value, 'f', 0, 64)
+}
+
+func formatBytes(value float64) string {
+	return strconv.FormatFloat(value/(1<<20), 'f', 1, 64) + " MiB"
+}
+
+func formatNanoseconds(value float64) string {
+	return time.Duration(value).String()
+}
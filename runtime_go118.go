@@ -0,0 +1,9 @@
+//go:build !go1.19
+
+package probes
+
+// goMemoryLimit возвращает 0: ограничение памяти среды
+// выполнения Go появилось в Go 1.19.
+func goMemoryLimit() uint64 {
+	return 0
+}
@@ -0,0 +1,20 @@
+//go:build go1.19
+
+package probes
+
+import (
+	"math"
+	"runtime/debug"
+)
+
+// goMemoryLimit возвращает ограничение памяти среды
+// выполнения Go, например из GOMEMLIMIT, либо 0, если
+// ограничение не задано.
+func goMemoryLimit() uint64 {
+	limit := debug.SetMemoryLimit(-1)
+	if limit <= 0 || limit == math.MaxInt64 {
+		return 0
+	}
+
+	return uint64(limit)
+}
@@ -0,0 +1,194 @@
+package probes
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewRuntimeChecker(t *testing.T) {
+	t.Parallel()
+
+	// Arrange.
+	tests := []struct {
+		name           string
+		options        []RuntimeOption
+		expectedResult Result
+		expectedErr    string
+	}{
+		{
+			name:           "Без порогов",
+			expectedResult: Success,
+		},
+		{
+			name:           "Пороги не превышены",
+			options:        []RuntimeOption{WithGoroutineLimit(1<<20, 1<<21), WithHeapLimit(1<<40, 1<<41)},
+			expectedResult: Success,
+		},
+		{
+			name:           "Выше мягкого порога горутин",
+			options:        []RuntimeOption{WithGoroutineLimit(1, 1<<20)},
+			expectedResult: Warning,
+			expectedErr:    "above 1",
+		},
+		{
+			name:           "Выше жёсткого порога кучи",
+			options:        []RuntimeOption{WithGoroutineLimit(1, 0), WithHeapLimit(0, 1)},
+			expectedResult: Failure,
+			expectedErr:    "heap ",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			probe := NewRuntimeChecker(test.options...)
+
+			// Act.
+			result, err := probe.Liveness(context.Background())
+
+			// Assert.
+			assert.Equal(t, test.expectedResult, result)
+
+			if test.expectedErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, test.expectedErr)
+			}
+		})
+	}
+}
+
+func TestRuntimeChecker_LivenessReport(t *testing.T) {
+	// Arrange.
+	probe := NewRuntimeChecker(WithGCPauseLimit(0, time.Nanosecond))
+
+	runtime.GC()
+
+	// Act.
+	first := probe.LivenessReport(context.Background())
+	second := probe.LivenessReport(context.Background())
+
+	// Assert.
+	assert.Equal(t, Failure, first.Result)
+	assert.ErrorContains(t, first.Err, "gc pause")
+	assert.Contains(t, first.Details, "goroutines")
+	assert.Contains(t, first.Details, "heap_alloc_bytes")
+	assert.Equal(t, Component{ID: "go", Type: "system", Metadata: map[string]string{"version": runtime.Version()}}, first.Component)
+
+	// Пауза остаётся в окне независимо от числа проверок.
+	assert.Equal(t, Failure, second.Result)
+	assert.ErrorContains(t, second.Err, "gc pause")
+}
+
+func TestRuntimeChecker_LivenessReport_HeapFraction(t *testing.T) {
+	t.Parallel()
+
+	// Arrange.
+	probe := &RuntimeChecker{heapFraction: &runtimeLimit{soft: 0.8, hard: 0.9}, gcPauseWindow: DefaultGCPauseWindow}
+
+	// Act.
+	report := probe.LivenessReport(context.Background())
+
+	// Assert.
+	assert.Equal(t, Success, report.Result)
+	assert.Equal(t, "not applied: memory limit is not set", report.Details["heap_fraction"])
+	assert.NotContains(t, report.Details, "memory_limit_bytes")
+}
+
+func TestMaxPause(t *testing.T) {
+	t.Parallel()
+
+	// Arrange.
+	now := time.Now()
+
+	stats := &runtime.MemStats{NumGC: 3}
+	stats.PauseNs[0], stats.PauseEnd[0] = uint64(time.Second), uint64(now.Add(-time.Hour).UnixNano())
+	stats.PauseNs[1], stats.PauseEnd[1] = uint64(time.Millisecond), uint64(now.Add(-time.Second).UnixNano())
+	stats.PauseNs[2], stats.PauseEnd[2] = uint64(time.Microsecond), uint64(now.UnixNano())
+
+	tests := []struct {
+		name          string
+		since         time.Time
+		expectedPause time.Duration
+	}{
+		{
+			name:          "Все сборки в окне",
+			since:         now.Add(-2 * time.Hour),
+			expectedPause: time.Second,
+		},
+		{
+			name:          "Старая сборка вне окна",
+			since:         now.Add(-time.Minute),
+			expectedPause: time.Millisecond,
+		},
+		{
+			name:          "Нет сборок в окне",
+			since:         now.Add(time.Second),
+			expectedPause: 0,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Act.
+			actualPause := maxPause(stats, test.since)
+
+			// Assert.
+			assert.Equal(t, test.expectedPause, actualPause)
+		})
+	}
+}
+
+func TestCgroupMemoryLimit(t *testing.T) {
+	t.Parallel()
+
+	// Arrange.
+	dir := t.TempDir()
+
+	write := func(name, content string) string {
+		file := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(file, []byte(content), 0o600))
+
+		return file
+	}
+
+	tests := []struct {
+		name     string
+		files    []string
+		expected uint64
+	}{
+		{
+			name:     "cgroup v2",
+			files:    []string{write("v2", "536870912\n")},
+			expected: 512 << 20,
+		},
+		{
+			name:     "cgroup v2 без ограничения",
+			files:    []string{write("v2-max", "max\n")},
+			expected: 0,
+		},
+		{
+			name:     "cgroup v1 без ограничения",
+			files:    []string{filepath.Join(dir, "missing"), write("v1", "9223372036854771712\n")},
+			expected: 0,
+		},
+		{
+			name:     "Файлы отсутствуют",
+			files:    []string{filepath.Join(dir, "missing")},
+			expected: 0,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Act.
+			limit := cgroupMemoryLimit(test.files)
+
+			// Assert.
+			assert.Equal(t, test.expected, limit)
+		})
+	}
+}
@@ -0,0 +1,167 @@
+package probes
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultSQLSaturation содержит долю занятых
+	// соединений пула по умолчанию, начиная с которой
+	// SQLChecker возвращает Warning.
+	DefaultSQLSaturation = 0.9
+
+	// DefaultSQLTimeout содержит время по умолчанию,
+	// отведённое SQLChecker на PingContext и проверочный
+	// запрос, совпадающее с timeoutSeconds пробы
+	// Kubernetes по умолчанию.
+	DefaultSQLTimeout = time.Second
+)
+
+// SQLOption настраивает SQLChecker.
+type SQLOption func(*SQLChecker)
+
+// WithSQLQuery задаёт проверочный запрос, который
+// выполняется после успешного PingContext, например
+// SELECT 1.
+func WithSQLQuery(query string) SQLOption {
+	return func(checker *SQLChecker) {
+		checker.query = query
+	}
+}
+
+// WithSQLSaturation задаёт долю занятых соединений от
+// MaxOpenConnections, начиная с которой SQLChecker
+// возвращает Warning.
+//
+// По умолчанию используется DefaultSQLSaturation.
+// Если размер пула не ограничен, доля не проверяется.
+func WithSQLSaturation(saturation float64) SQLOption {
+	return func(checker *SQLChecker) {
+		checker.saturation = saturation
+	}
+}
+
+// WithSQLTimeout задаёт время, отведённое на
+// PingContext и проверочный запрос.
+//
+// По умолчанию используется DefaultSQLTimeout.
+func WithSQLTimeout(timeout time.Duration) SQLOption {
+	return func(checker *SQLChecker) {
+		checker.timeout = timeout
+	}
+}
+
+// SQLChecker реализует Readiness-пробу пула соединений
+// database/sql.
+//
+// Проба возвращает Failure, если PingContext или
+// проверочный запрос WithSQLQuery завершились ошибкой, и
+// Warning, если пул насыщен: доля занятых соединений
+// достигла WithSQLSaturation или с предыдущей проверки
+// появились запросы, ожидавшие свободного соединения.
+//
+// Статистика пула DB.Stats передаётся в Report.Details.
+//
+// Для инициализации необходимо использовать метод
+// NewSQLChecker.
+type SQLChecker struct {
+	db         *sql.DB
+	query      string
+	saturation float64
+	timeout    time.Duration
+
+	mu        sync.Mutex
+	waitCount int64
+}
+
+// NewSQLChecker инициализирует Readiness-пробу пула
+// соединений db.
+func NewSQLChecker(db *sql.DB, options ...SQLOption) *SQLChecker {
+	checker := &SQLChecker{
+		db:         db,
+		saturation: DefaultSQLSaturation,
+		timeout:    DefaultSQLTimeout,
+		waitCount:  db.Stats().WaitCount,
+	}
+
+	for _, option := range options {
+		option(checker)
+	}
+
+	return checker
+}
+
+func (checker *SQLChecker) Readiness(ctx context.Context) (Result, error) {
+	return checker.ReadinessReport(ctx).Unwrap()
+}
+
+// ReadinessReport возвращает отчёт Readiness-пробы со
+// статистикой пула соединений.
+func (checker *SQLChecker) ReadinessReport(ctx context.Context) Report {
+	report := Report{Result: Success, Component: Component{Type: "datastore"}}
+
+	if checker.timeout > 0 {
+		var cancel context.CancelFunc
+
+		ctx, cancel = context.WithTimeout(ctx, checker.timeout)
+		defer cancel()
+	}
+
+	if err := checker.db.PingContext(ctx); err != nil {
+		report.Result, report.Err = Failure, fmt.Errorf("ping: %w", err)
+	} else if err := checker.validate(ctx); err != nil {
+		report.Result, report.Err = Failure, fmt.Errorf("query: %w", err)
+	}
+
+	stats := checker.db.Stats()
+
+	report.Details = map[string]interface{}{
+		"open_connections":     stats.OpenConnections,
+		"in_use":               stats.InUse,
+		"idle":                 stats.Idle,
+		"max_open_connections": stats.MaxOpenConnections,
+		"wait_count":           stats.WaitCount,
+		"wait_duration_ms":     milliseconds(stats.WaitDuration),
+	}
+
+	checker.mu.Lock()
+	waits := stats.WaitCount - checker.waitCount
+	checker.waitCount = stats.WaitCount
+	checker.mu.Unlock()
+
+	if report.Result.IsFailure() {
+		return report
+	}
+
+	saturated := stats.MaxOpenConnections > 0 &&
+		float64(stats.InUse) >= checker.saturation*float64(stats.MaxOpenConnections)
+
+	if saturated || waits > 0 {
+		report.Result = Warning
+		report.Message = fmt.Sprintf("pool saturated: %d/%d connections in use, %d waits since last check",
+			stats.InUse, stats.MaxOpenConnections, waits)
+	}
+
+	return report
+}
+
+func (checker *SQLChecker) validate(ctx context.Context) error {
+	if checker.query == "" {
+		return nil
+	}
+
+	rows, err := checker.db.QueryContext(ctx, checker.query)
+	if err != nil {
+		return err
+	}
+
+	if err := rows.Close(); err != nil {
+		return err
+	}
+
+	return rows.Err()
+}
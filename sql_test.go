@@ -0,0 +1,173 @@
+package probes
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testSQLConnector struct {
+	pingErr  error
+	queryErr error
+	hang     bool
+}
+
+func (connector testSQLConnector) Connect(context.Context) (driver.Conn, error) {
+	return testSQLConn(connector), nil
+}
+
+func (connector testSQLConnector) Driver() driver.Driver {
+	return nil
+}
+
+type testSQLConn testSQLConnector
+
+func (conn testSQLConn) Prepare(string) (driver.Stmt, error) {
+	return nil, errors.New("probes: prepare is not supported")
+}
+
+func (conn testSQLConn) Close() error {
+	return nil
+}
+
+func (conn testSQLConn) Begin() (driver.Tx, error) {
+	return nil, errors.New("probes: transactions are not supported")
+}
+
+func (conn testSQLConn) Ping(ctx context.Context) error {
+	if conn.hang {
+		<-ctx.Done()
+
+		return ctx.Err()
+	}
+
+	return conn.pingErr
+}
+
+func (conn testSQLConn) QueryContext(context.Context, string, []driver.NamedValue) (driver.Rows, error) {
+	if conn.queryErr != nil {
+		return nil, conn.queryErr
+	}
+
+	return testSQLRows{}, nil
+}
+
+type testSQLRows struct{}
+
+func (rows testSQLRows) Columns() []string {
+	return []string{"?column?"}
+}
+
+func (rows testSQLRows) Close() error {
+	return nil
+}
+
+func (rows testSQLRows) Next([]driver.Value) error {
+	return io.EOF
+}
+
+func TestSQLChecker_Readiness(t *testing.T) {
+	t.Parallel()
+
+	// Arrange.
+	tests := []struct {
+		name           string
+		connector      testSQLConnector
+		options        []SQLOption
+		expectedResult Result
+		expectedErr    string
+	}{
+		{
+			name:           "Пул доступен",
+			connector:      testSQLConnector{},
+			options:        []SQLOption{WithSQLQuery("SELECT 1")},
+			expectedResult: Success,
+			expectedErr:    "",
+		},
+		{
+			name:           "Ошибка соединения",
+			connector:      testSQLConnector{pingErr: errDummyProbe},
+			expectedResult: Failure,
+			expectedErr:    "ping: probes: dummy error",
+		},
+		{
+			name:           "Истёк срок проверки",
+			connector:      testSQLConnector{hang: true},
+			options:        []SQLOption{WithSQLTimeout(10 * time.Millisecond)},
+			expectedResult: Failure,
+			expectedErr:    "ping: context deadline exceeded",
+		},
+		{
+			name:           "Ошибка проверочного запроса",
+			connector:      testSQLConnector{queryErr: errDummyProbe},
+			options:        []SQLOption{WithSQLQuery("SELECT 1")},
+			expectedResult: Failure,
+			expectedErr:    "query: probes: dummy error",
+		},
+		{
+			name:           "Запрос не задан",
+			connector:      testSQLConnector{queryErr: errDummyProbe},
+			expectedResult: Success,
+			expectedErr:    "",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			db := sql.OpenDB(test.connector)
+			defer db.Close()
+
+			// Act.
+			result, err := NewSQLChecker(db, test.options...).Readiness(context.Background())
+
+			// Assert.
+			assert.Equal(t, test.expectedResult, result)
+
+			if test.expectedErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, test.expectedErr)
+			}
+		})
+	}
+}
+
+func TestSQLChecker_ReadinessReport_Saturation(t *testing.T) {
+	t.Parallel()
+
+	// Arrange.
+	ctx := context.Background()
+
+	db := sql.OpenDB(testSQLConnector{})
+	defer db.Close()
+
+	db.SetMaxOpenConns(2)
+
+	conn, err := db.Conn(ctx)
+	require.NoError(t, err)
+
+	defer conn.Close()
+
+	checker := NewSQLChecker(db, WithSQLSaturation(0.5))
+
+	// Act.
+	report := checker.ReadinessReport(ctx)
+
+	// Assert.
+	assert.Equal(t, Warning, report.Result)
+	assert.NoError(t, report.Err)
+	assert.Equal(t, "pool saturated: 1/2 connections in use, 0 waits since last check", report.Message)
+	assert.Equal(t, 1, report.Details["in_use"])
+	assert.Equal(t, 2, report.Details["max_open_connections"])
+
+	require.NoError(t, conn.Close())
+
+	report = checker.ReadinessReport(ctx)
+	assert.Equal(t, Success, report.Result)
+}
@@ -0,0 +1,224 @@
+package probes
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// Threshold сглаживает кратковременные сбои пробы
+// подобно failureThreshold и successThreshold Kubernetes.
+//
+// Threshold переходит в состояние Failure только после
+// заданного числа последовательных Failure, а обратно –
+// после заданного числа последовательных Success или
+// Warning. Пока число сбоев не достигло порога, проба
+// возвращает Warning, чтобы ошибка оставалась видна в
+// теле ответа. Unknown не влияет на счётчики и
+// возвращается без изменений, а в состоянии Failure
+// заменяется на Failure, чтобы не скрывать сбой.
+//
+// Счётчики Liveness-, Readiness- и Startup-проб ведутся
+// раздельно. Расширенные отчёты и именованные проверки
+// исходных проб, например CompositeProbes, передаются
+// HTTP-обработчикам: порог применяется к результату
+// отчёта, а именованные проверки возвращаются без
+// сглаживания.
+//
+// Для инициализации необходимо использовать метод
+// NewThreshold.
+type Threshold struct {
+	probes Probes
+
+	failureThreshold int
+	successThreshold int
+
+	mu        sync.Mutex
+	liveness  thresholdState
+	readiness thresholdState
+	startup   thresholdState
+}
+
+// NewThreshold инициализирует обёртку над пробами, которая
+// переходит в Failure после failureThreshold
+// последовательных сбоев и возвращается после
+// successThreshold последовательных успешных проверок.
+//
+// Значения меньше единицы заменяются единицей. Функцию
+// можно обернуть, преобразовав её к ProbeFunc, а пробу
+// одного вида – методами NewLivenessThreshold,
+// NewReadinessThreshold и NewStartupThreshold.
+func NewThreshold(probes Probes, failureThreshold, successThreshold int) *Threshold {
+	if failureThreshold < 1 {
+		failureThreshold = 1
+	}
+
+	if successThreshold < 1 {
+		successThreshold = 1
+	}
+
+	return &Threshold{
+		probes:           probes,
+		failureThreshold: failureThreshold,
+		successThreshold: successThreshold,
+	}
+}
+
+// NewLivenessThreshold инициализирует обёртку над
+// Liveness-пробой, например RuntimeChecker, со
+// сглаживанием порогами.
+//
+// В отличие от преобразования к ProbeFunc, сохраняются
+// расширенный отчёт и именованные проверки пробы. Все
+// виды проб обёртки выполняют её.
+//
+//	Смотри NewThreshold
+func NewLivenessThreshold(probe Liveness, failureThreshold, successThreshold int) *Threshold {
+	return NewThreshold(livenessOnly(probe), failureThreshold, successThreshold)
+}
+
+// NewReadinessThreshold инициализирует обёртку над
+// Readiness-пробой, например SQLChecker или
+// UpstreamChecker, со сглаживанием порогами.
+//
+//	Смотри NewLivenessThreshold
+func NewReadinessThreshold(probe Readiness, failureThreshold, successThreshold int) *Threshold {
+	return NewThreshold(readinessOnly(probe), failureThreshold, successThreshold)
+}
+
+// NewStartupThreshold инициализирует обёртку над
+// Startup-пробой со сглаживанием порогами.
+//
+//	Смотри NewLivenessThreshold
+func NewStartupThreshold(probe Startup, failureThreshold, successThreshold int) *Threshold {
+	return NewThreshold(startupOnly(probe), failureThreshold, successThreshold)
+}
+
+func (probe *Threshold) Liveness(ctx context.Context) (Result, error) {
+	return probe.LivenessReport(ctx).Unwrap()
+}
+
+func (probe *Threshold) Readiness(ctx context.Context) (Result, error) {
+	return probe.ReadinessReport(ctx).Unwrap()
+}
+
+func (probe *Threshold) Startup(ctx context.Context) (Result, error) {
+	return probe.StartupReport(ctx).Unwrap()
+}
+
+// LivenessReport возвращает отчёт Liveness-пробы
+// исходных проб с результатом, сглаженным порогами.
+func (probe *Threshold) LivenessReport(ctx context.Context) Report {
+	return probe.check(&probe.liveness, livenessReporter(probe.probes)(ctx))
+}
+
+// ReadinessReport возвращает отчёт Readiness-пробы
+// исходных проб с результатом, сглаженным порогами.
+func (probe *Threshold) ReadinessReport(ctx context.Context) Report {
+	return probe.check(&probe.readiness, readinessReporter(probe.probes)(ctx))
+}
+
+// StartupReport возвращает отчёт Startup-пробы
+// исходных проб с результатом, сглаженным порогами.
+func (probe *Threshold) StartupReport(ctx context.Context) Report {
+	return probe.check(&probe.startup, startupReporter(probe.probes)(ctx))
+}
+
+// LivenessCheck выполняет именованную проверку
+// Liveness-пробы исходных проб, если они её
+// поддерживают. Результат проверки не сглаживается.
+//
+//	Смотри CompositeProbes.LivenessCheck
+func (probe *Threshold) LivenessCheck(ctx context.Context, name string) (CheckResult, bool) {
+	return livenessCheckRunner(probe.probes)(ctx, name)
+}
+
+// ReadinessCheck выполняет именованную проверку
+// Readiness-пробы исходных проб, если они её
+// поддерживают. Результат проверки не сглаживается.
+//
+//	Смотри CompositeProbes.ReadinessCheck
+func (probe *Threshold) ReadinessCheck(ctx context.Context, name string) (CheckResult, bool) {
+	return readinessCheckRunner(probe.probes)(ctx, name)
+}
+
+// StartupCheck выполняет именованную проверку
+// Startup-пробы исходных проб, если они её
+// поддерживают. Результат проверки не сглаживается.
+//
+//	Смотри CompositeProbes.StartupCheck
+func (probe *Threshold) StartupCheck(ctx context.Context, name string) (CheckResult, bool) {
+	return startupCheckRunner(probe.probes)(ctx, name)
+}
+
+type thresholdState struct {
+	failed    bool
+	failures  int
+	successes int
+}
+
+func (probe *Threshold) check(state *thresholdState, report Report) Report {
+	result, err := report.Unwrap()
+	if result.Validate() != nil {
+		result, err = Failure, ErrUnsupportedResult
+	}
+
+	probe.mu.Lock()
+	defer probe.mu.Unlock()
+
+	report.Result, report.Err = probe.apply(state, result, err)
+
+	return report
+}
+
+func (probe *Threshold) apply(state *thresholdState, result Result, err error) (Result, error) {
+	if result.IsUnknown() {
+		if state.failed {
+			return Failure, cause(result, err)
+		}
+
+		return result, err
+	}
+
+	if result.IsFailure() {
+		state.failures++
+		state.successes = 0
+
+		if !state.failed && state.failures >= probe.failureThreshold {
+			state.failed = true
+		}
+
+		if state.failed {
+			return Failure, err
+		}
+
+		return Warning, fmt.Errorf("%d/%d consecutive failures: %w", state.failures, probe.failureThreshold, cause(result, err))
+	}
+
+	state.successes++
+	state.failures = 0
+
+	if state.failed && state.successes >= probe.successThreshold {
+		state.failed = false
+	}
+
+	if state.failed {
+		message := fmt.Sprintf("%d/%d consecutive successes after failure", state.successes, probe.successThreshold)
+		if err != nil {
+			return Failure, fmt.Errorf("%s: %w", message, err)
+		}
+
+		return Failure, errors.New(message)
+	}
+
+	return result, err
+}
+
+func cause(result Result, err error) error {
+	if err != nil {
+		return err
+	}
+
+	return errors.New(result.String())
+}
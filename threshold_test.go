@@ -0,0 +1,175 @@
+package probes
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewThreshold(t *testing.T) {
+	t.Parallel()
+
+	// Act.
+	probe := NewThreshold(testProbe(Success, nil), 0, -1)
+
+	// Assert.
+	assert.Equal(t, 1, probe.failureThreshold)
+	assert.Equal(t, 1, probe.successThreshold)
+}
+
+func TestThreshold_Readiness(t *testing.T) {
+	t.Parallel()
+
+	// Arrange.
+	type step struct {
+		result         Result
+		err            error
+		expectedResult Result
+		expectedErr    string
+	}
+
+	tests := []struct {
+		name  string
+		steps []step
+	}{
+		{
+			name: "Сбои не достигли порога",
+			steps: []step{
+				{result: Failure, err: errDummyProbe, expectedResult: Warning, expectedErr: "1/3 consecutive failures: probes: dummy error"},
+				{result: Failure, expectedResult: Warning, expectedErr: "2/3 consecutive failures: failure"},
+				{result: Success, expectedResult: Success},
+				{result: Failure, err: errDummyProbe, expectedResult: Warning, expectedErr: "1/3 consecutive failures: probes: dummy error"},
+			},
+		},
+		{
+			name: "Сбои достигли порога и проба восстановилась",
+			steps: []step{
+				{result: Failure, expectedResult: Warning, expectedErr: "1/3 consecutive failures: failure"},
+				{result: Failure, expectedResult: Warning, expectedErr: "2/3 consecutive failures: failure"},
+				{result: Failure, err: errDummyProbe, expectedResult: Failure, expectedErr: "probes: dummy error"},
+				{result: Success, expectedResult: Failure, expectedErr: "1/2 consecutive successes after failure"},
+				{result: Failure, expectedResult: Failure},
+				{result: Warning, err: errDummyProbe, expectedResult: Failure, expectedErr: "1/2 consecutive successes after failure: probes: dummy error"},
+				{result: Success, expectedResult: Success},
+			},
+		},
+		{
+			name: "Unknown не скрывает состояние Failure",
+			steps: []step{
+				{result: Unknown, expectedResult: Unknown},
+				{result: Failure, expectedResult: Warning, expectedErr: "1/3 consecutive failures: failure"},
+				{result: Failure, expectedResult: Warning, expectedErr: "2/3 consecutive failures: failure"},
+				{result: Failure, err: errDummyProbe, expectedResult: Failure, expectedErr: "probes: dummy error"},
+				{result: Unknown, expectedResult: Failure, expectedErr: "unknown"},
+				{result: Unknown, err: errDummyProbe, expectedResult: Failure, expectedErr: "probes: dummy error"},
+				{result: Success, expectedResult: Failure, expectedErr: "1/2 consecutive successes after failure"},
+			},
+		},
+		{
+			name: "Неподдерживаемый результат считается сбоем",
+			steps: []step{
+				{result: 100, expectedResult: Warning, expectedErr: "1/3 consecutive failures: probes: unsupported result"},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var current step
+
+			probe := NewThreshold(ProbeFunc(func(context.Context) (Result, error) {
+				return current.result, current.err
+			}), 3, 2)
+
+			for _, current = range test.steps {
+				// Act.
+				result, err := probe.Readiness(context.Background())
+
+				// Assert.
+				assert.Equal(t, current.expectedResult, result)
+
+				if current.expectedErr == "" {
+					assert.NoError(t, err)
+
+					continue
+				}
+
+				assert.EqualError(t, err, current.expectedErr)
+			}
+		})
+	}
+}
+
+func TestThreshold_Liveness(t *testing.T) {
+	t.Parallel()
+
+	// Arrange.
+	probe := NewThreshold(testProbe(Failure, errDummyProbe), 1, 1)
+
+	// Act.
+	result, err := probe.Liveness(context.Background())
+
+	// Assert.
+	assert.Equal(t, Failure, result)
+	assert.True(t, errors.Is(err, errDummyProbe))
+}
+
+func TestThreshold_Handler(t *testing.T) {
+	t.Parallel()
+
+	// Arrange.
+	composite := NewCompositeProbes().
+		RegisterLiveness("deadlock", testProbe(Success, nil)).
+		RegisterReadiness("postgres", testProbe(Success, nil)).
+		RegisterReadiness("redis", testProbe(Failure, errDummyProbe))
+
+	server := NewHTTPServer().Probes(NewThreshold(composite, 2, 1))
+
+	serve := func(target string) (int, string) {
+		recorder := httptest.NewRecorder()
+
+		server.Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, target, nil))
+
+		return recorder.Code, recorder.Body.String()
+	}
+
+	// Act.
+	firstStatus, firstBody := serve("/readiness?verbose")
+	livenessStatus, _ := serve("/liveness/deadlock")
+	checkStatus, _ := serve("/readiness/redis")
+	secondStatus, _ := serve("/readiness")
+
+	// Assert.
+	assert.Equal(t, http.StatusOK, firstStatus)
+	assert.Equal(t, "[+]postgres ok\n[-]redis failed: probes: dummy error\nreadiness check passed\n", firstBody)
+
+	assert.Equal(t, http.StatusOK, livenessStatus)
+	assert.Equal(t, http.StatusInternalServerError, checkStatus)
+	assert.Equal(t, http.StatusInternalServerError, secondStatus)
+}
+
+func TestNewReadinessThreshold(t *testing.T) {
+	t.Parallel()
+
+	// Arrange.
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer upstream.Close()
+
+	probe := NewReadinessThreshold(NewUpstreamChecker(upstream.URL), 2, 1)
+
+	// Act.
+	first := probe.ReadinessReport(context.Background())
+	second := probe.ReadinessReport(context.Background())
+
+	// Assert.
+	assert.Equal(t, Warning, first.Result)
+	assert.Equal(t, Failure, second.Result)
+	assert.True(t, errors.Is(second.Err, ErrUnexpectedResponse))
+	assert.Equal(t, http.StatusServiceUnavailable, second.Details["status_code"])
+	assert.Equal(t, "component", second.Component.Type)
+}
@@ -0,0 +1,229 @@
+package probes
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// ErrTaskFailed указывает, что задача запуска завершилась
+// ошибкой, не указав её причину.
+//
+//	Смотри StartupTask.Fail
+var ErrTaskFailed = errors.New("probes: startup task failed")
+
+// TaskOption настраивает StartupTask при регистрации.
+type TaskOption func(*StartupTask)
+
+// WithOptionalTask помечает задачу запуска как
+// необязательную: Startup не ожидает её завершения, а её
+// ошибка переводит пробу только в Warning.
+func WithOptionalTask() TaskOption {
+	return func(task *StartupTask) {
+		task.required = false
+	}
+}
+
+// StartupTracker отслеживает выполнение задач запуска
+// приложения: миграций, прогрева кэша, загрузки
+// конфигурации и т.п.
+//
+// Компоненты регистрируют задачи методом
+// StartupTracker.Task и отмечают их выполнение методами
+// StartupTask.Done и StartupTask.Fail. Startup возвращает
+// Failure, пока не выполнены все обязательные задачи, а
+// ошибка содержит прогресс запуска, например
+// "3/5 done, waiting on: cache-warmup".
+//
+// Для инициализации необходимо использовать метод
+// NewStartupTracker.
+type StartupTracker struct {
+	mu    sync.RWMutex
+	tasks []*StartupTask
+}
+
+// NewStartupTracker инициализирует пустой StartupTracker.
+func NewStartupTracker() *StartupTracker {
+	return &StartupTracker{}
+}
+
+// Task регистрирует задачу запуска с указанным именем и
+// возвращает её.
+//
+// Если задача с таким именем уже зарегистрирована,
+// возвращается она, а options игнорируются. Задачи
+// обязательны, если не указано WithOptionalTask.
+func (tracker *StartupTracker) Task(name string, options ...TaskOption) *StartupTask {
+	tracker.mu.Lock()
+	defer tracker.mu.Unlock()
+
+	for _, task := range tracker.tasks {
+		if task.name == name {
+			return task
+		}
+	}
+
+	task := &StartupTask{tracker: tracker, name: name, required: true}
+
+	for _, option := range options {
+		option(task)
+	}
+
+	tracker.tasks = append(tracker.tasks, task)
+
+	return task
+}
+
+// Progress возвращает текущий прогресс запуска.
+func (tracker *StartupTracker) Progress() StartupProgress {
+	tracker.mu.RLock()
+	defer tracker.mu.RUnlock()
+
+	progress := StartupProgress{Total: len(tracker.tasks)}
+
+	for _, task := range tracker.tasks {
+		switch task.state {
+		case taskDone:
+			progress.Done++
+		case taskFailed:
+			progress.Failed = append(progress.Failed, TaskFailure{Name: task.name, Required: task.required, Err: task.err})
+		case taskPending:
+			if task.required {
+				progress.Waiting = append(progress.Waiting, task.name)
+			}
+		}
+	}
+
+	return progress
+}
+
+// Startup возвращает Success, когда выполнены все
+// обязательные задачи запуска.
+//
+// Пока обязательные задачи не выполнены или одна из них
+// завершилась ошибкой, возвращается Failure. Ошибка
+// необязательной задачи возвращается как Warning.
+func (tracker *StartupTracker) Startup(context.Context) (Result, error) {
+	progress := tracker.Progress()
+
+	switch {
+	case len(progress.Waiting) > 0 || progress.failedRequired():
+		return Failure, errors.New(progress.String())
+	case len(progress.Failed) > 0:
+		return Warning, errors.New(progress.String())
+	default:
+		return Success, nil
+	}
+}
+
+type taskState uint8
+
+const (
+	taskPending taskState = iota
+	taskDone
+	taskFailed
+)
+
+// StartupTask содержит задачу запуска, выполнение
+// которой отслеживает StartupTracker.
+//
+// Для инициализации необходимо использовать метод
+// StartupTracker.Task.
+type StartupTask struct {
+	tracker  *StartupTracker
+	name     string
+	required bool
+
+	state taskState
+	err   error
+}
+
+// Name возвращает имя задачи запуска.
+func (task *StartupTask) Name() string {
+	return task.name
+}
+
+// Done отмечает задачу запуска выполненной, в том числе
+// после повторной попытки, завершившейся ошибкой.
+func (task *StartupTask) Done() {
+	task.tracker.mu.Lock()
+	defer task.tracker.mu.Unlock()
+
+	task.state, task.err = taskDone, nil
+}
+
+// Fail отмечает задачу запуска завершившейся ошибкой err.
+//
+// Если err равна nil, используется ErrTaskFailed.
+func (task *StartupTask) Fail(err error) {
+	if err == nil {
+		err = ErrTaskFailed
+	}
+
+	task.tracker.mu.Lock()
+	defer task.tracker.mu.Unlock()
+
+	task.state, task.err = taskFailed, err
+}
+
+// StartupProgress содержит прогресс запуска приложения.
+//
+//	Смотри StartupTracker.Progress
+type StartupProgress struct {
+	// Total содержит число зарегистрированных задач.
+	Total int
+
+	// Done содержит число выполненных задач.
+	Done int
+
+	// Waiting содержит имена обязательных задач, которые
+	// ещё выполняются, в порядке регистрации.
+	Waiting []string
+
+	// Failed содержит задачи, завершившиеся ошибкой, в
+	// порядке регистрации.
+	Failed []TaskFailure
+}
+
+// TaskFailure содержит задачу запуска, завершившуюся
+// ошибкой.
+type TaskFailure struct {
+	Name     string
+	Required bool
+	Err      error
+}
+
+// String возвращает прогресс запуска в виде
+// "3/5 done, failed: migrations: <ошибка>, waiting on: cache-warmup".
+func (progress StartupProgress) String() string {
+	var builder strings.Builder
+
+	fmt.Fprintf(&builder, "%d/%d done", progress.Done, progress.Total)
+
+	if len(progress.Failed) > 0 {
+		failed := make([]string, 0, len(progress.Failed))
+		for _, failure := range progress.Failed {
+			failed = append(failed, failure.Name+": "+failure.Err.Error())
+		}
+
+		builder.WriteString(", failed: " + strings.Join(failed, "; "))
+	}
+
+	if len(progress.Waiting) > 0 {
+		builder.WriteString(", waiting on: " + strings.Join(progress.Waiting, ", "))
+	}
+
+	return builder.String()
+}
+
+func (progress StartupProgress) failedRequired() bool {
+	for _, failure := range progress.Failed {
+		if failure.Required {
+			return true
+		}
+	}
+
+	return false
+}
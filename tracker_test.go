@@ -0,0 +1,128 @@
+package probes
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStartupTracker_Startup(t *testing.T) {
+	t.Parallel()
+
+	// Arrange.
+	tests := []struct {
+		name           string
+		arrange        func(tracker *StartupTracker)
+		expectedResult Result
+		expectedErr    string
+	}{
+		{
+			name:           "Нет задач",
+			arrange:        func(*StartupTracker) {},
+			expectedResult: Success,
+		},
+		{
+			name: "Задачи выполняются",
+			arrange: func(tracker *StartupTracker) {
+				tracker.Task("migrations").Done()
+				tracker.Task("config").Done()
+				tracker.Task("cache-warmup")
+				tracker.Task("search-index")
+				tracker.Task("geoip", WithOptionalTask())
+			},
+			expectedResult: Failure,
+			expectedErr:    "2/5 done, waiting on: cache-warmup, search-index",
+		},
+		{
+			name: "Обязательная задача завершилась ошибкой",
+			arrange: func(tracker *StartupTracker) {
+				tracker.Task("migrations").Fail(errDummyProbe)
+				tracker.Task("cache-warmup")
+			},
+			expectedResult: Failure,
+			expectedErr:    "0/2 done, failed: migrations: probes: dummy error, waiting on: cache-warmup",
+		},
+		{
+			name: "Необязательная задача завершилась ошибкой",
+			arrange: func(tracker *StartupTracker) {
+				tracker.Task("migrations").Done()
+				tracker.Task("geoip", WithOptionalTask()).Fail(nil)
+			},
+			expectedResult: Warning,
+			expectedErr:    "1/2 done, failed: geoip: probes: startup task failed",
+		},
+		{
+			name: "Необязательная задача выполняется",
+			arrange: func(tracker *StartupTracker) {
+				tracker.Task("migrations").Done()
+				tracker.Task("geoip", WithOptionalTask())
+			},
+			expectedResult: Success,
+		},
+		{
+			name: "Повторная попытка выполнена",
+			arrange: func(tracker *StartupTracker) {
+				tracker.Task("migrations").Fail(errDummyProbe)
+				tracker.Task("migrations").Done()
+			},
+			expectedResult: Success,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tracker := NewStartupTracker()
+			test.arrange(tracker)
+
+			// Act.
+			result, err := tracker.Startup(context.Background())
+
+			// Assert.
+			assert.Equal(t, test.expectedResult, result)
+
+			if test.expectedErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, test.expectedErr)
+			}
+		})
+	}
+}
+
+func TestStartupTracker_Task(t *testing.T) {
+	t.Parallel()
+
+	// Arrange.
+	tracker := NewStartupTracker()
+
+	// Act.
+	first := tracker.Task("migrations")
+	second := tracker.Task("migrations", WithOptionalTask())
+
+	// Assert.
+	assert.Same(t, first, second)
+	assert.Equal(t, "migrations", second.Name())
+	assert.True(t, second.required)
+}
+
+func TestStartupTracker_Progress(t *testing.T) {
+	t.Parallel()
+
+	// Arrange.
+	tracker := NewStartupTracker()
+
+	tracker.Task("migrations").Done()
+	tracker.Task("cache-warmup")
+	tracker.Task("geoip", WithOptionalTask()).Fail(errDummyProbe)
+
+	// Act.
+	progress := tracker.Progress()
+
+	// Assert.
+	assert.Equal(t, StartupProgress{
+		Total:   3,
+		Done:    1,
+		Waiting: []string{"cache-warmup"},
+		Failed:  []TaskFailure{{Name: "geoip", Required: false, Err: errDummyProbe}},
+	}, progress)
+}
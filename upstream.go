@@ -0,0 +1,267 @@
+package probes
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"time"
+)
+
+const (
+	// DefaultUpstreamTimeout содержит время по умолчанию,
+	// отведённое UpstreamChecker на запрос, совпадающее
+	// с timeoutSeconds пробы Kubernetes по умолчанию.
+	DefaultUpstreamTimeout = time.Second
+
+	// upstreamBodyLimit ограничивает размер тела ответа,
+	// которое читается для сравнения.
+	upstreamBodyLimit = 1 << 20
+)
+
+// ErrUnexpectedResponse указывает, что ответ вышестоящего
+// сервиса не прошёл проверку UpstreamChecker.
+var ErrUnexpectedResponse = errors.New("probes: unexpected upstream response")
+
+// UpstreamOption настраивает UpstreamChecker.
+type UpstreamOption func(*UpstreamChecker)
+
+// WithUpstreamMethod задаёт HTTP-метод запроса.
+//
+// По умолчанию используется GET.
+func WithUpstreamMethod(method string) UpstreamOption {
+	return func(checker *UpstreamChecker) {
+		checker.method = method
+	}
+}
+
+// WithUpstreamStatuses задаёт ожидаемые коды ответа.
+//
+// По умолчанию ожидается любой код 2xx.
+func WithUpstreamStatuses(statuses ...int) UpstreamOption {
+	return func(checker *UpstreamChecker) {
+		checker.statuses = statuses
+	}
+}
+
+// WithUpstreamHeader добавляет заголовок запроса,
+// например Authorization.
+func WithUpstreamHeader(key, value string) UpstreamOption {
+	return func(checker *UpstreamChecker) {
+		checker.header.Add(key, value)
+	}
+}
+
+// WithUpstreamBody задаёт подстроку, которую должно
+// содержать тело ответа.
+func WithUpstreamBody(substring string) UpstreamOption {
+	return func(checker *UpstreamChecker) {
+		checker.substring = substring
+	}
+}
+
+// WithUpstreamBodyPattern задаёт регулярное выражение,
+// которому должно соответствовать тело ответа.
+func WithUpstreamBodyPattern(pattern *regexp.Regexp) UpstreamOption {
+	return func(checker *UpstreamChecker) {
+		checker.pattern = pattern
+	}
+}
+
+// WithUpstreamTLS задаёт настройки TLS, например
+// корневые сертификаты или клиентский сертификат.
+//
+// Не применяется вместе с WithUpstreamClient.
+func WithUpstreamTLS(config *tls.Config) UpstreamOption {
+	return func(checker *UpstreamChecker) {
+		checker.tls = config
+	}
+}
+
+// WithUpstreamClient задаёт HTTP-клиент запросов.
+func WithUpstreamClient(client *http.Client) UpstreamOption {
+	return func(checker *UpstreamChecker) {
+		checker.client = client
+	}
+}
+
+// WithUpstreamTimeout задаёт время, отведённое на
+// запрос, включая чтение тела ответа.
+//
+// По умолчанию используется DefaultUpstreamTimeout.
+func WithUpstreamTimeout(timeout time.Duration) UpstreamOption {
+	return func(checker *UpstreamChecker) {
+		checker.timeout = timeout
+	}
+}
+
+// WithUpstreamLatencyBudget задаёт время ответа, после
+// которого успешная проверка возвращает Warning.
+func WithUpstreamLatencyBudget(budget time.Duration) UpstreamOption {
+	return func(checker *UpstreamChecker) {
+		checker.budget = budget
+	}
+}
+
+// UpstreamChecker реализует Readiness-пробу вышестоящего
+// HTTP-сервиса.
+//
+// Проба возвращает Failure, если запрос завершился
+// ошибкой или ответ не прошёл проверку кода и тела
+// (ErrUnexpectedResponse), и Warning, если ответ получен
+// позже WithUpstreamLatencyBudget.
+//
+// Код ответа и время ответа передаются в Report.Details.
+//
+// Для инициализации необходимо использовать метод
+// NewUpstreamChecker.
+type UpstreamChecker struct {
+	url      string
+	method   string
+	header   http.Header
+	statuses []int
+
+	substring string
+	pattern   *regexp.Regexp
+
+	tls     *tls.Config
+	client  *http.Client
+	timeout time.Duration
+	budget  time.Duration
+}
+
+// NewUpstreamChecker инициализирует Readiness-пробу
+// вышестоящего HTTP-сервиса по адресу target.
+func NewUpstreamChecker(target string, options ...UpstreamOption) *UpstreamChecker {
+	checker := &UpstreamChecker{
+		url:     target,
+		method:  http.MethodGet,
+		header:  make(http.Header),
+		timeout: DefaultUpstreamTimeout,
+	}
+
+	for _, option := range options {
+		option(checker)
+	}
+
+	if checker.client == nil {
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		if checker.tls != nil {
+			transport.TLSClientConfig = checker.tls
+		}
+
+		checker.client = &http.Client{Transport: transport}
+	}
+
+	return checker
+}
+
+func (checker *UpstreamChecker) Readiness(ctx context.Context) (Result, error) {
+	return checker.ReadinessReport(ctx).Unwrap()
+}
+
+// ReadinessReport возвращает отчёт Readiness-пробы с
+// кодом и временем ответа вышестоящего сервиса.
+func (checker *UpstreamChecker) ReadinessReport(ctx context.Context) Report {
+	report := Report{Result: Success, Component: Component{ID: componentID(checker.url), Type: "component"}}
+
+	if checker.timeout > 0 {
+		var cancel context.CancelFunc
+
+		ctx, cancel = context.WithTimeout(ctx, checker.timeout)
+		defer cancel()
+	}
+
+	started := time.Now()
+
+	status, err := checker.call(ctx)
+
+	latency := time.Since(started)
+
+	report.Details = map[string]interface{}{"latency_ms": milliseconds(latency)}
+	if status != 0 {
+		report.Details["status_code"] = status
+	}
+
+	switch {
+	case err != nil:
+		report.Result, report.Err = Failure, err
+	case checker.budget > 0 && latency > checker.budget:
+		report.Result = Warning
+		report.Message = fmt.Sprintf("slow response: %s exceeds latency budget %s",
+			latency.Truncate(time.Millisecond), checker.budget)
+	}
+
+	return report
+}
+
+func (checker *UpstreamChecker) call(ctx context.Context) (int, error) {
+	request, err := http.NewRequestWithContext(ctx, checker.method, checker.url, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	for key, values := range checker.header {
+		request.Header[key] = values
+	}
+
+	// net/http игнорирует заголовок Host в request.Header.
+	if host := request.Header.Get("Host"); host != "" {
+		request.Host = host
+	}
+
+	response, err := checker.client.Do(request)
+	if err != nil {
+		return 0, err
+	}
+	defer response.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(response.Body, upstreamBodyLimit))
+	if err != nil {
+		return response.StatusCode, err
+	}
+
+	if !checker.expected(response.StatusCode) {
+		return response.StatusCode, fmt.Errorf("%w: status %d", ErrUnexpectedResponse, response.StatusCode)
+	}
+
+	if checker.substring != "" && !bytes.Contains(body, []byte(checker.substring)) {
+		return response.StatusCode, fmt.Errorf("%w: body does not contain %q", ErrUnexpectedResponse, checker.substring)
+	}
+
+	if checker.pattern != nil && !checker.pattern.Match(body) {
+		return response.StatusCode, fmt.Errorf("%w: body does not match %q", ErrUnexpectedResponse, checker.pattern)
+	}
+
+	return response.StatusCode, nil
+}
+
+func (checker *UpstreamChecker) expected(status int) bool {
+	if len(checker.statuses) == 0 {
+		return status >= http.StatusOK && status < http.StatusMultipleChoices
+	}
+
+	for _, expected := range checker.statuses {
+		if status == expected {
+			return true
+		}
+	}
+
+	return false
+}
+
+// componentID возвращает хост адреса target в качестве
+// идентификатора компонента.
+func componentID(target string) string {
+	parsed, err := url.Parse(target)
+	if err != nil || parsed.Host == "" {
+		return target
+	}
+
+	return parsed.Host
+}
@@ -0,0 +1,158 @@
+package probes
+
+import (
+	"context"
+	"crypto/tls"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpstreamChecker_Readiness(t *testing.T) {
+	t.Parallel()
+
+	// Arrange.
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/health":
+			if r.Header.Get("Authorization") != "Bearer secret" {
+				w.WriteHeader(http.StatusUnauthorized)
+
+				return
+			}
+
+			_, _ = w.Write([]byte(`{"status":"pass","version":"1.2.3"}`))
+		case "/slow":
+			time.Sleep(20 * time.Millisecond)
+		case "/hang":
+			time.Sleep(time.Second)
+		default:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name           string
+		path           string
+		options        []UpstreamOption
+		expectedResult Result
+		expectedErr    string
+	}{
+		{
+			name: "Ожидаемый ответ",
+			path: "/health",
+			options: []UpstreamOption{
+				WithUpstreamHeader("Authorization", "Bearer secret"),
+				WithUpstreamBody(`"status":"pass"`),
+				WithUpstreamBodyPattern(regexp.MustCompile(`"version":"1\.\d+`)),
+			},
+			expectedResult: Success,
+		},
+		{
+			name:           "Неожиданный код ответа",
+			path:           "/health",
+			expectedResult: Failure,
+			expectedErr:    "probes: unexpected upstream response: status 401",
+		},
+		{
+			name:           "Заданные коды ответа",
+			path:           "/unavailable",
+			options:        []UpstreamOption{WithUpstreamStatuses(http.StatusOK, http.StatusServiceUnavailable)},
+			expectedResult: Success,
+		},
+		{
+			name: "Тело без подстроки",
+			path: "/health",
+			options: []UpstreamOption{
+				WithUpstreamHeader("Authorization", "Bearer secret"),
+				WithUpstreamBody(`"status":"fail"`),
+			},
+			expectedResult: Failure,
+			expectedErr:    `probes: unexpected upstream response: body does not contain "\"status\":\"fail\""`,
+		},
+		{
+			name: "Тело не соответствует выражению",
+			path: "/health",
+			options: []UpstreamOption{
+				WithUpstreamHeader("Authorization", "Bearer secret"),
+				WithUpstreamBodyPattern(regexp.MustCompile(`"version":"2\.`)),
+			},
+			expectedResult: Failure,
+			expectedErr:    `probes: unexpected upstream response: body does not match "\"version\":\"2\\."`,
+		},
+		{
+			name:           "Превышен бюджет задержки",
+			path:           "/slow",
+			options:        []UpstreamOption{WithUpstreamMethod(http.MethodHead), WithUpstreamLatencyBudget(time.Millisecond)},
+			expectedResult: Warning,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Act.
+			result, err := NewUpstreamChecker(server.URL+test.path, test.options...).Readiness(context.Background())
+
+			// Assert.
+			assert.Equal(t, test.expectedResult, result)
+
+			switch {
+			case test.expectedResult.IsWarning():
+				assert.Error(t, err)
+			case test.expectedErr == "":
+				assert.NoError(t, err)
+			default:
+				assert.EqualError(t, err, test.expectedErr)
+			}
+		})
+	}
+
+	t.Run("Истекло время запроса", func(t *testing.T) {
+		// Act.
+		result, err := NewUpstreamChecker(server.URL+"/hang", WithUpstreamTimeout(10*time.Millisecond)).
+			Readiness(context.Background())
+
+		// Assert.
+		assert.Equal(t, Failure, result)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+}
+
+func TestUpstreamChecker_ReadinessReport_TLS(t *testing.T) {
+	t.Parallel()
+
+	// Arrange.
+	server := httptest.NewTLSServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+	defer server.Close()
+
+	tests := []struct {
+		name           string
+		tls            *tls.Config
+		expectedResult Result
+	}{
+		{
+			name:           "Недоверенный сертификат",
+			tls:            nil,
+			expectedResult: Failure,
+		},
+		{
+			name:           "Доверенный сертификат",
+			tls:            server.Client().Transport.(*http.Transport).TLSClientConfig,
+			expectedResult: Success,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Act.
+			report := NewUpstreamChecker(server.URL, WithUpstreamTLS(test.tls)).ReadinessReport(context.Background())
+
+			// Assert.
+			assert.Equal(t, test.expectedResult, report.Result)
+			assert.Equal(t, server.Listener.Addr().String(), report.Component.ID)
+		})
+	}
+}